@@ -9,6 +9,10 @@ import (
 
 const (
 	DefaultPort int = 40000
+
+	// sort order for ranked queries
+	OrderAscending  string = "asc"
+	OrderDescending string = "desc"
 )
 
 var (
@@ -17,4 +21,10 @@ var (
 
 	// ErrRabbitMgrNil the rabbit manager has not been initialized
 	ErrRabbitMgrNil = errors.New("the rabbit manager has not been initialized")
+
+	// ErrDriverNil the neo4j driver has not been initialized
+	ErrDriverNil = errors.New("the neo4j driver has not been initialized")
+
+	// ErrNotificationMgrNil the notification manager has not been started
+	ErrNotificationMgrNil = errors.New("the notification manager has not been started")
 )
@@ -5,9 +5,21 @@ package router
 
 import (
 	"errors"
+	"time"
+)
+
+const (
+	// how often an active backpressure signal is re-broadcast for proxies that started after it was sent
+	DefaultBackpressureInterval time.Duration = 5 * time.Second
+
+	// proxies drop a backpressure signal not refreshed within this many intervals
+	DefaultBackpressureTTLFactor = 3
 )
 
 var (
 	// ErrInvalidInput required input was not found
 	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrConversationIdMissing the message did not contain a conversation id
+	ErrConversationIdMissing = errors.New("the message did not contain a conversation id")
 )
@@ -42,6 +42,12 @@ func (ch ConversationInitHandler) ProcessMessage(byData []byte) error {
 		return err
 	}
 
+	// validate
+	if len(im.Message.Data.ConversationID) == 0 {
+		klog.V(1).Infof("[ConversationInit] conversationId is empty. Dropping message.\n")
+		return ErrConversationIdMissing
+	}
+
 	/*
 		Create Application Channel Publisher
 
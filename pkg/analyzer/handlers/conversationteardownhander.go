@@ -42,6 +42,12 @@ func (ch ConversationTeardownHandler) ProcessMessage(byData []byte) error {
 		return err
 	}
 
+	// validate
+	if len(tm.Message.Data.ConversationID) == 0 {
+		klog.V(1).Infof("[ConversationTeardown] conversationId is empty. Dropping message.\n")
+		return ErrConversationIdMissing
+	}
+
 	/*
 		Delete Application Channel Publisher
 
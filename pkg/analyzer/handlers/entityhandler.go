@@ -4,9 +4,17 @@
 package router
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
 	prettyjson "github.com/hokaccha/go-prettyjson"
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	klog "k8s.io/klog/v2"
 
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	journal "github.com/dvonthenen/enterprise-reference-implementation/pkg/journal"
 	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
 )
 
@@ -16,6 +24,8 @@ func NewEntityHandler(options HandlerOptions) *rabbitinterfaces.RabbitMessageHan
 		session:     options.Session,
 		symblClient: options.SymblClient,
 		manager:     options.Manager,
+		pauseList:   options.PauseList,
+		journal:     options.Journal,
 	}
 	return &handler
 }
@@ -31,7 +41,73 @@ func (eh EntityHandler) ProcessMessage(byData []byte) error {
 	klog.V(2).Infof("EntityHandler:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// reform struct
+	var er interfaces.EntityResponse
+	err = json.Unmarshal(byData, &er)
+	if err != nil {
+		klog.V(1).Infof("[EntityHandler] json.Unmarshal failed. Err: %v\n", err)
+		return err
+	}
+
+	// validate
+	if len(er.ConversationID) == 0 {
+		klog.V(1).Infof("[EntityHandler] conversationId is empty. Dropping message.\n")
+		return ErrConversationIdMissing
+	}
+	if eh.pauseList.IsPaused(er.ConversationID) {
+		klog.V(3).Infof("[EntityHandler] conversationId (%s) is paused. Skipping message.\n", er.ConversationID)
+		return nil
+	}
+
 	// TODO: template for add your businesss logic
 
+	/*
+		Example: Build an entity co-mention network
+
+		Every entity in this response is linked to the other entities referenced by the same message.
+		The weight is recomputed from the graph as the number of messages both entities are referenced
+		by, so it doesn't depend on the order responses arrive in and redelivered messages don't
+		inflate it.
+	*/
+	if er.EntityResponse == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, entity := range er.EntityResponse.Entities {
+		entityId := fmt.Sprintf("%s_%s_%s", entity.Type, entity.SubType, entity.Category)
+
+		for _, match := range entity.Matches {
+			for _, msgRef := range match.MessageRefs {
+				_, err := journal.ExecuteWrite(ctx, eh.journal, *eh.session, func(tx neo4j.ManagedTransaction) (any, error) {
+					myQuery := interfaces.ReplaceIndexes(`
+						MATCH (e:Entity { #entity_index#: $entity_id })-[:ENTITY_MESSAGE_REF]-(m:Message { #message_index#: $message_id })-[:ENTITY_MESSAGE_REF]-(o:Entity)
+						WHERE o.#entity_index# <> $entity_id
+						WITH DISTINCT e, o
+						MERGE (e)-[r:CO_MENTIONED_WITH]-(o)
+						WITH e, o, r
+						MATCH (e)-[:ENTITY_MESSAGE_REF]-(s:Message)-[:ENTITY_MESSAGE_REF]-(o)
+						WITH r, count(DISTINCT s) AS shared
+						SET r.weight = shared
+						`)
+					result, err := tx.Run(ctx, myQuery, map[string]any{
+						"entity_id":  entityId,
+						"message_id": msgRef.ID,
+					})
+					if err != nil {
+						return nil, err
+					}
+					return result.Collect(ctx)
+				})
+				if err != nil {
+					klog.V(1).Infof("[EntityHandler] ExecuteWrite failed. Err: %v\n", err)
+					return err
+				}
+			}
+		}
+	}
+
 	return nil
 }
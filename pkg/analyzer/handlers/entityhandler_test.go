@@ -0,0 +1,80 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package router
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	fake "github.com/dvonthenen/enterprise-reference-implementation/pkg/internal/fake"
+)
+
+func TestEntityHandlerCoMentionStatements(t *testing.T) {
+	fakeSession := &fake.Session{}
+	var session neo4j.SessionWithContext = fakeSession
+
+	handler := NewEntityHandler(HandlerOptions{Session: &session})
+
+	// person_a_x is in message m1, person_b_x in m1 and m2
+	msg := []byte(`{
+		"conversationId": "conv1",
+		"entityResponse": {
+			"entities": [
+				{"type": "person", "subType": "a", "category": "x", "matches": [{"messageRefs": [{"id": "m1"}]}]},
+				{"type": "person", "subType": "b", "category": "x", "matches": [{"messageRefs": [{"id": "m1"}, {"id": "m2"}]}]}
+			]
+		}
+	}`)
+
+	if err := (*handler).ProcessMessage(msg); err != nil {
+		t.Fatalf("ProcessMessage() err = %v", err)
+	}
+
+	// one statement per message ref
+	statements := fakeSession.Statements()
+	wantParams := []map[string]any{
+		{"entity_id": "person_a_x", "message_id": "m1"},
+		{"entity_id": "person_b_x", "message_id": "m1"},
+		{"entity_id": "person_b_x", "message_id": "m2"},
+	}
+	if len(statements) != len(wantParams) {
+		t.Fatalf("ran %d statements, want %d", len(statements), len(wantParams))
+	}
+
+	for i, statement := range statements {
+		if !reflect.DeepEqual(statement.Parameters, wantParams[i]) {
+			t.Errorf("statement %d params = %v, want %v", i, statement.Parameters, wantParams[i])
+		}
+
+		// pairs through the same message and recomputes the weight, nothing is incremented
+		cypher := strings.Join(strings.Fields(statement.Cypher), " ")
+		for _, want := range []string{
+			"(e:Entity { entityId: $entity_id })-[:ENTITY_MESSAGE_REF]-(m:Message { messageId: $message_id })-[:ENTITY_MESSAGE_REF]-(o:Entity)",
+			"WHERE o.entityId <> $entity_id",
+			"MERGE (e)-[r:CO_MENTIONED_WITH]-(o)",
+			"MATCH (e)-[:ENTITY_MESSAGE_REF]-(s:Message)-[:ENTITY_MESSAGE_REF]-(o)",
+			"count(DISTINCT s) AS shared",
+			"SET r.weight = shared",
+		} {
+			if !strings.Contains(cypher, want) {
+				t.Errorf("statement %d is missing %q:\n%s", i, want, cypher)
+			}
+		}
+		if strings.Contains(cypher, "r.weight + ") {
+			t.Errorf("statement %d increments the weight:\n%s", i, cypher)
+		}
+	}
+
+	// a redelivery sends the same statements, which leave the weight unchanged
+	if err := (*handler).ProcessMessage(msg); err != nil {
+		t.Fatalf("ProcessMessage() err = %v", err)
+	}
+	redelivered := fakeSession.Statements()[len(statements):]
+	if !reflect.DeepEqual(redelivered, statements) {
+		t.Errorf("redelivery ran %v, want %v", redelivered, statements)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package router
+
+import (
+	"errors"
+	"testing"
+
+	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
+)
+
+func TestProcessMessageMissingConversationId(t *testing.T) {
+	tests := []struct {
+		name string
+		init func(HandlerOptions) *rabbitinterfaces.RabbitMessageHandler
+	}{
+		{"ConversationInit", NewConversationInitHandler},
+		{"ConversationTeardown", NewConversationTeardownHandler},
+		{"Entity", NewEntityHandler},
+		{"Insight", NewInsightHandler},
+		{"Message", NewMessageHandler},
+		{"Topic", NewTopicHandler},
+		{"Tracker", NewTrackerHandler},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// zero value options, the handler must bail before using the session or manager
+			handler := tt.init(HandlerOptions{})
+
+			err := (*handler).ProcessMessage([]byte(`{}`))
+			if !errors.Is(err, ErrConversationIdMissing) {
+				t.Errorf("ProcessMessage() err = %v, want %v", err, ErrConversationIdMissing)
+			}
+		})
+	}
+}
@@ -4,9 +4,12 @@
 package router
 
 import (
+	"encoding/json"
+
 	prettyjson "github.com/hokaccha/go-prettyjson"
 	klog "k8s.io/klog/v2"
 
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
 	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
 )
 
@@ -16,6 +19,7 @@ func NewInsightHandler(options HandlerOptions) *rabbitinterfaces.RabbitMessageHa
 		session:     options.Session,
 		symblClient: options.SymblClient,
 		manager:     options.Manager,
+		pauseList:   options.PauseList,
 	}
 	return &handler
 }
@@ -31,6 +35,24 @@ func (ih InsightHandler) ProcessMessage(byData []byte) error {
 	klog.V(2).Infof("InsightHandler:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// reform struct
+	var ir interfaces.InsightResponse
+	err = json.Unmarshal(byData, &ir)
+	if err != nil {
+		klog.V(1).Infof("[InsightHandler] json.Unmarshal failed. Err: %v\n", err)
+		return err
+	}
+
+	// validate
+	if len(ir.ConversationID) == 0 {
+		klog.V(1).Infof("[InsightHandler] conversationId is empty. Dropping message.\n")
+		return ErrConversationIdMissing
+	}
+	if ih.pauseList.IsPaused(ir.ConversationID) {
+		klog.V(3).Infof("[InsightHandler] conversationId (%s) is paused. Skipping message.\n", ir.ConversationID)
+		return nil
+	}
+
 	// TODO: template for add your businesss logic
 
 	return nil
@@ -5,6 +5,8 @@ package router
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	klog "k8s.io/klog/v2"
@@ -14,10 +16,18 @@ import (
 )
 
 func NewNotificationManager(options NotificationManagerOption) *NotificationManager {
+	if options.BackpressureInterval <= 0 {
+		options.BackpressureInterval = DefaultBackpressureInterval
+	}
+
 	mgr := &NotificationManager{
-		driver:        options.Driver,
-		rabbitManager: options.RabbitManager,
-		symblClient:   options.SymblClient,
+		driver:               options.Driver,
+		rabbitManager:        options.RabbitManager,
+		symblClient:          options.SymblClient,
+		cipher:               options.Cipher,
+		pauseList:            NewPauseList(),
+		journal:              options.Journal,
+		backpressureInterval: options.BackpressureInterval,
 	}
 	return mgr
 }
@@ -78,6 +88,9 @@ func (nm *NotificationManager) Init() error {
 			Session:     &session,
 			SymblClient: nm.symblClient,
 			Manager:     nm.rabbitManager,
+			Cipher:      nm.cipher,
+			PauseList:   nm.pauseList,
+			Journal:     nm.journal,
 		})
 
 		_, err := (*nm.rabbitManager).CreateSubscriber(rabbitinterfaces.SubscriberOptions{
@@ -99,15 +112,101 @@ func (nm *NotificationManager) Init() error {
 		return err
 	}
 
+	/*
+		Create Backpressure Channel Publisher
+
+		This is used to signal all Dataminer proxies to slow down or pause publishing
+	*/
+	_, err = (*nm.rabbitManager).CreatePublisher(rabbitinterfaces.PublisherOptions{
+		Name:        interfaces.RabbitExchangeBackpressure,
+		Type:        rabbitinterfaces.ExchangeTypeFanout,
+		AutoDeleted: true,
+		IfUnused:    true,
+	})
+	if err != nil {
+		klog.V(1).Infof("CreatePublisher %s failed. Err: %v\n", interfaces.RabbitExchangeBackpressure, err)
+		klog.V(6).Infof("NotificationManager.Init LEAVE\n")
+		return err
+	}
+
+	// the exchange isn't durable, keep repeating an active signal for proxies that start later
+	nm.stopChan = make(chan struct{})
+	go nm.rebroadcastBackpressure(nm.stopChan)
+
 	klog.V(4).Infof("Init Succeeded\n")
 	klog.V(6).Infof("NotificationManager.Init LEAVE\n")
 
 	return nil
 }
 
+func (nm *NotificationManager) PauseConversation(conversationId string) {
+	nm.pauseList.Pause(conversationId)
+}
+
+func (nm *NotificationManager) ResumeConversation(conversationId string) {
+	nm.pauseList.Resume(conversationId)
+}
+
+func (nm *NotificationManager) SignalBackpressure(pause bool, delay time.Duration) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	// survives two missed re-broadcasts before proxies drop it
+	ttl := DefaultBackpressureTTLFactor * nm.backpressureInterval
+
+	nm.backpressure = interfaces.BackpressureMessage{
+		Type:    interfaces.ControlMessageTypeBackpressure,
+		Pause:   pause,
+		DelayMs: delay.Milliseconds(),
+		TtlMs:   ttl.Milliseconds(),
+	}
+
+	return nm.publishBackpressure()
+}
+
+func (nm *NotificationManager) rebroadcastBackpressure(stopChan chan struct{}) {
+	ticker := time.NewTicker(nm.backpressureInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			nm.mu.Lock()
+			if nm.backpressure.Pause || nm.backpressure.DelayMs > 0 {
+				_ = nm.publishBackpressure()
+			}
+			nm.mu.Unlock()
+		}
+	}
+}
+
+// publishBackpressure must be called with nm.mu held so a re-broadcast never lands after a newer signal
+func (nm *NotificationManager) publishBackpressure() error {
+	data, err := json.Marshal(nm.backpressure)
+	if err != nil {
+		klog.V(1).Infof("BackpressureMessage json.Marshal failed. Err: %v\n", err)
+		return err
+	}
+
+	err = (*nm.rabbitManager).PublishMessageByName(interfaces.RabbitExchangeBackpressure, data)
+	if err != nil {
+		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
 func (nm *NotificationManager) Teardown() error {
 	klog.V(6).Infof("NotificationManager.Teardown ENTER\n")
 
+	if nm.stopChan != nil {
+		close(nm.stopChan)
+		nm.stopChan = nil
+	}
+
 	err := (*nm.rabbitManager).Teardown()
 	if err != nil {
 		klog.V(1).Infof("rabbitManager.Teardown failed. Err: %v\n", err)
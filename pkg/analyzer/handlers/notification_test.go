@@ -0,0 +1,81 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package router
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	fake "github.com/dvonthenen/enterprise-reference-implementation/pkg/internal/fake"
+	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
+)
+
+func TestBackpressureRebroadcast(t *testing.T) {
+	fakeManager := &fake.Manager{Published: make(chan fake.Message, 100)}
+	var manager rabbitinterfaces.Manager = fakeManager
+	var driver neo4j.DriverWithContext = &fake.Driver{Session: &fake.Session{}}
+
+	nm := NewNotificationManager(NotificationManagerOption{
+		Driver:               &driver,
+		RabbitManager:        &manager,
+		BackpressureInterval: 10 * time.Millisecond,
+	})
+	if err := nm.Init(); err != nil {
+		t.Fatalf("Init() err = %v", err)
+	}
+	defer nm.Teardown()
+
+	next := func(timeout time.Duration) (interfaces.BackpressureMessage, bool) {
+		select {
+		case msg := <-fakeManager.Published:
+			var bm interfaces.BackpressureMessage
+			if err := json.Unmarshal(msg.Data, &bm); err != nil {
+				t.Fatalf("json.Unmarshal() err = %v", err)
+			}
+			return bm, true
+		case <-time.After(timeout):
+			return interfaces.BackpressureMessage{}, false
+		}
+	}
+
+	if err := nm.SignalBackpressure(true, 0); err != nil {
+		t.Fatalf("SignalBackpressure() err = %v", err)
+	}
+
+	// the signal plus at least two re-broadcasts
+	for i := 0; i < 3; i++ {
+		bm, ok := next(time.Second)
+		if !ok {
+			t.Fatalf("message %d was not published", i+1)
+		}
+		if bm.Type != interfaces.ControlMessageTypeBackpressure || !bm.Pause {
+			t.Errorf("message %d = %+v, want pause", i+1, bm)
+		}
+		if bm.TtlMs != 30 {
+			t.Errorf("message %d ttl = %dms, want 3 intervals (30ms)", i+1, bm.TtlMs)
+		}
+	}
+
+	if err := nm.SignalBackpressure(false, 0); err != nil {
+		t.Fatalf("SignalBackpressure() err = %v", err)
+	}
+
+	// drain re-broadcasts already in flight, the clear is published once and then nothing
+	for {
+		bm, ok := next(time.Second)
+		if !ok {
+			t.Fatalf("clear was not published")
+		}
+		if !bm.Pause {
+			break
+		}
+	}
+	if bm, ok := next(50 * time.Millisecond); ok {
+		t.Errorf("published %+v after the signal was cleared", bm)
+	}
+}
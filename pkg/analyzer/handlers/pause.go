@@ -0,0 +1,34 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package router
+
+func NewPauseList() *PauseList {
+	pl := &PauseList{
+		paused: make(map[string]bool),
+	}
+	return pl
+}
+
+func (pl *PauseList) Pause(conversationId string) {
+	pl.mu.Lock()
+	pl.paused[conversationId] = true
+	pl.mu.Unlock()
+}
+
+func (pl *PauseList) Resume(conversationId string) {
+	pl.mu.Lock()
+	delete(pl.paused, conversationId)
+	pl.mu.Unlock()
+}
+
+func (pl *PauseList) IsPaused(conversationId string) bool {
+	if pl == nil {
+		return false
+	}
+
+	pl.mu.RLock()
+	defer pl.mu.RUnlock()
+
+	return pl.paused[conversationId]
+}
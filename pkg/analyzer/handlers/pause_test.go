@@ -0,0 +1,94 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package router
+
+import (
+	"testing"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	fake "github.com/dvonthenen/enterprise-reference-implementation/pkg/internal/fake"
+	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
+)
+
+func TestPauseList(t *testing.T) {
+	var nilList *PauseList
+	if nilList.IsPaused("conv1") {
+		t.Errorf("nil IsPaused() = true, want false")
+	}
+
+	pl := NewPauseList()
+	if pl.IsPaused("conv1") {
+		t.Errorf("IsPaused() before Pause = true, want false")
+	}
+
+	pl.Pause("conv1")
+	if !pl.IsPaused("conv1") {
+		t.Errorf("IsPaused() after Pause = false, want true")
+	}
+	if pl.IsPaused("conv2") {
+		t.Errorf("IsPaused() other conversation = true, want false")
+	}
+
+	// pausing twice and resuming an unknown id are no-ops
+	pl.Pause("conv1")
+	pl.Resume("conv2")
+	if !pl.IsPaused("conv1") {
+		t.Errorf("IsPaused() after repeat Pause = false, want true")
+	}
+
+	pl.Resume("conv1")
+	if pl.IsPaused("conv1") {
+		t.Errorf("IsPaused() after Resume = true, want false")
+	}
+}
+
+func TestPausedConversationSkipped(t *testing.T) {
+	tests := []struct {
+		name string
+		init func(HandlerOptions) *rabbitinterfaces.RabbitMessageHandler
+		msg  string
+	}{
+		{"Topic", NewTopicHandler, `{"conversationId": "conv1", "topicResponse": {"topics": [{"id": "t1"}]}}`},
+		{"Tracker", NewTrackerHandler, `{"conversationId": "conv1", "trackerResponse": {"trackers": [{"id": "t1", "name": "tracker"}]}}`},
+		{"Entity", NewEntityHandler, `{"conversationId": "conv1", "entityResponse": {"entities": [{"type": "person", "matches": [{"messageRefs": [{"id": "m1"}]}]}]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeSession := &fake.Session{}
+			var session neo4j.SessionWithContext = fakeSession
+
+			pauseList := NewPauseList()
+			pauseList.Pause("conv1")
+
+			handler := tt.init(HandlerOptions{Session: &session, PauseList: pauseList})
+
+			err := (*handler).ProcessMessage([]byte(tt.msg))
+			if err != nil {
+				t.Errorf("ProcessMessage() err = %v, want nil", err)
+			}
+			if statements := fakeSession.Statements(); len(statements) != 0 {
+				t.Errorf("ProcessMessage() ran %d statements, want 0", len(statements))
+			}
+		})
+	}
+
+	// the same entity message is written once resumed
+	fakeSession := &fake.Session{}
+	var session neo4j.SessionWithContext = fakeSession
+
+	pauseList := NewPauseList()
+	pauseList.Pause("conv1")
+	pauseList.Resume("conv1")
+
+	handler := NewEntityHandler(HandlerOptions{Session: &session, PauseList: pauseList})
+	err := (*handler).ProcessMessage([]byte(tests[2].msg))
+	if err != nil {
+		t.Fatalf("ProcessMessage() err = %v", err)
+	}
+	if statements := fakeSession.Statements(); len(statements) == 0 {
+		t.Errorf("ProcessMessage() ran no statements after Resume")
+	}
+}
@@ -4,9 +4,12 @@
 package router
 
 import (
+	"encoding/json"
+
 	prettyjson "github.com/hokaccha/go-prettyjson"
 	klog "k8s.io/klog/v2"
 
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
 	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
 )
 
@@ -16,6 +19,7 @@ func NewTopicHandler(options HandlerOptions) *rabbitinterfaces.RabbitMessageHand
 		session:     options.Session,
 		symblClient: options.SymblClient,
 		manager:     options.Manager,
+		pauseList:   options.PauseList,
 	}
 	return &handler
 }
@@ -31,6 +35,24 @@ func (ch TopicHandler) ProcessMessage(byData []byte) error {
 	klog.V(2).Infof("TopicHandler:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// reform struct
+	var tr interfaces.TopicResponse
+	err = json.Unmarshal(byData, &tr)
+	if err != nil {
+		klog.V(1).Infof("[TopicHandler] json.Unmarshal failed. Err: %v\n", err)
+		return err
+	}
+
+	// validate
+	if len(tr.ConversationID) == 0 {
+		klog.V(1).Infof("[TopicHandler] conversationId is empty. Dropping message.\n")
+		return ErrConversationIdMissing
+	}
+	if ch.pauseList.IsPaused(tr.ConversationID) {
+		klog.V(3).Infof("[TopicHandler] conversationId (%s) is paused. Skipping message.\n", tr.ConversationID)
+		return nil
+	}
+
 	// TODO: template for add your businesss logic
 
 	return nil
@@ -22,6 +22,8 @@ func NewTrackerHandler(options HandlerOptions) *rabbitinterfaces.RabbitMessageHa
 		session:     options.Session,
 		symblClient: options.SymblClient,
 		manager:     options.Manager,
+		pauseList:   options.PauseList,
+		cipher:      options.Cipher,
 	}
 	return &handler
 }
@@ -45,6 +47,16 @@ func (ch TrackerHandler) ProcessMessage(byData []byte) error {
 		return err
 	}
 
+	// validate
+	if len(tr.ConversationID) == 0 {
+		klog.V(1).Infof("[TrackerHandler] conversationId is empty. Dropping message.\n")
+		return ErrConversationIdMissing
+	}
+	if ch.pauseList.IsPaused(tr.ConversationID) {
+		klog.V(3).Infof("[TrackerHandler] conversationId (%s) is paused. Skipping message.\n", tr.ConversationID)
+		return nil
+	}
+
 	// TODO: template for add your businesss logic
 
 	/*
@@ -75,10 +87,16 @@ func (ch TrackerHandler) ProcessMessage(byData []byte) error {
 				relationship := result.Record().Values[1].(neo4j.Relationship)
 				user := result.Record().Values[4].(neo4j.Node)
 
+				content, err := interfaces.DecryptProperty(ch.cipher, message.Props, interfaces.DatabasePropertyContent)
+				if err != nil {
+					klog.V(1).Infof("DecryptProperty failed. Err: %v\n", err)
+					return nil, err
+				}
+
 				klog.V(3).Infof("Previous Tracker [Message]\n")
 				klog.V(3).Infof("Author: %s / %s\n", user.Props["name"].(string), user.Props["email"].(string))
 				klog.V(3).Infof("Tracker Match: %s\n", relationship.Props["value"].(string))
-				klog.V(3).Infof("Corresponding sentence: %s\n", message.Props["content"].(string))
+				klog.V(3).Infof("Corresponding sentence: %s\n", content)
 
 				for _, match := range tracker.Matches {
 					for _, refs := range match.MessageRefs {
@@ -95,7 +113,7 @@ func (ch TrackerHandler) ProcessMessage(byData []byte) error {
 									Correlation:     tracker.Name,
 									CurrentContent:  refs.Text,
 									CurrentMatch:    match.Value,
-									PreviousContent: message.Props["content"].(string),
+									PreviousContent: content,
 									PreviousMatch:   relationship.Props["value"].(string),
 								},
 							},
@@ -147,10 +165,16 @@ func (ch TrackerHandler) ProcessMessage(byData []byte) error {
 				relationship := result.Record().Values[1].(neo4j.Relationship)
 				user := result.Record().Values[4].(neo4j.Node)
 
+				content, err := interfaces.DecryptProperty(ch.cipher, insight.Props, interfaces.DatabasePropertyContent)
+				if err != nil {
+					klog.V(1).Infof("DecryptProperty failed. Err: %v\n", err)
+					return nil, err
+				}
+
 				klog.V(3).Infof("Previous Tracker [Insight]\n")
 				klog.V(3).Infof("Author: %s / %s\n", user.Props["name"].(string), user.Props["email"].(string))
 				klog.V(3).Infof("Tracker Match: %s\n", relationship.Props["value"].(string))
-				klog.V(3).Infof("Corresponding sentence: %s\n", insight.Props["content"].(string))
+				klog.V(3).Infof("Corresponding sentence: %s\n", content)
 
 				for _, match := range tracker.Matches {
 					for _, refs := range match.InsightRefs {
@@ -168,7 +192,7 @@ func (ch TrackerHandler) ProcessMessage(byData []byte) error {
 									Correlation:     tracker.Name,
 									CurrentContent:  refs.Text,
 									CurrentMatch:    match.Value,
-									PreviousContent: insight.Props["content"].(string),
+									PreviousContent: content,
 									PreviousMatch:   relationship.Props["value"].(string),
 								},
 							},
@@ -4,9 +4,14 @@
 package router
 
 import (
+	"sync"
+	"time"
+
 	symbl "github.com/dvonthenen/symbl-go-sdk/pkg/client"
 	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	journal "github.com/dvonthenen/enterprise-reference-implementation/pkg/journal"
 	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
 )
 
@@ -17,6 +22,9 @@ type HandlerOptions struct {
 	Session     *neo4j.SessionWithContext // retrieve insights
 	SymblClient *symbl.RestClient
 	Manager     *rabbitinterfaces.Manager
+	Cipher      interfaces.Cipher // decrypt encrypted properties
+	PauseList   *PauseList        // conversations to skip
+	Journal     *journal.Journal  // log executed writes
 }
 
 type ConversationInitHandler struct {
@@ -29,30 +37,37 @@ type EntityHandler struct {
 	session     *neo4j.SessionWithContext
 	symblClient *symbl.RestClient
 	manager     *rabbitinterfaces.Manager
+	pauseList   *PauseList
+	journal     *journal.Journal
 }
 
 type InsightHandler struct {
 	session     *neo4j.SessionWithContext
 	symblClient *symbl.RestClient
 	manager     *rabbitinterfaces.Manager
+	pauseList   *PauseList
 }
 
 type MessageHandler struct {
 	session     *neo4j.SessionWithContext
 	symblClient *symbl.RestClient
 	manager     *rabbitinterfaces.Manager
+	pauseList   *PauseList
 }
 
 type TopicHandler struct {
 	session     *neo4j.SessionWithContext
 	symblClient *symbl.RestClient
 	manager     *rabbitinterfaces.Manager
+	pauseList   *PauseList
 }
 
 type TrackerHandler struct {
 	session     *neo4j.SessionWithContext
 	symblClient *symbl.RestClient
 	manager     *rabbitinterfaces.Manager
+	cipher      interfaces.Cipher
+	pauseList   *PauseList
 }
 
 type ConversationTeardownHandler struct {
@@ -61,6 +76,16 @@ type ConversationTeardownHandler struct {
 	manager     *rabbitinterfaces.Manager
 }
 
+/*
+	Conversations whose messages are acked and skipped by the analyzer handlers
+
+	This doesn't reach the Dataminer, which keeps writing a paused conversation into neo4j.
+*/
+type PauseList struct {
+	paused map[string]bool
+	mu     sync.RWMutex
+}
+
 /*
 	Notification mmanager
 */
@@ -68,6 +93,11 @@ type NotificationManagerOption struct {
 	Driver        *neo4j.DriverWithContext
 	RabbitManager *rabbitinterfaces.Manager
 	SymblClient   *symbl.RestClient
+	Cipher        interfaces.Cipher
+	Journal       *journal.Journal
+
+	// re-broadcast an active backpressure signal, zero uses DefaultBackpressureInterval
+	BackpressureInterval time.Duration
 }
 
 type NotificationManager struct {
@@ -79,4 +109,19 @@ type NotificationManager struct {
 
 	// rabbit
 	rabbitManager *rabbitinterfaces.Manager
+
+	// field level encryption
+	cipher interfaces.Cipher
+
+	// paused conversations
+	pauseList *PauseList
+
+	// cypher journal
+	journal *journal.Journal
+
+	// backpressure signal, re-broadcast while active
+	backpressure         interfaces.BackpressureMessage
+	backpressureInterval time.Duration
+	stopChan             chan struct{}
+	mu                   sync.Mutex
 }
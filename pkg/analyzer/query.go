@@ -0,0 +1,214 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	klog "k8s.io/klog/v2"
+
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+)
+
+// GetEntityNetwork returns the entities co-mentioned with entityName (the entityId, ie
+// type_subType_category) whose CO_MENTIONED_WITH weight is at least minWeight, strongest first
+func (s *Server) GetEntityNetwork(ctx context.Context, entityName string, minWeight int, limit int) ([]EntityLink, error) {
+	klog.V(6).Infof("Server.GetEntityNetwork ENTER\n")
+
+	if len(entityName) == 0 || limit <= 0 {
+		klog.V(1).Infof("entityName or limit is invalid\n")
+		klog.V(6).Infof("Server.GetEntityNetwork LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	if s.driver == nil {
+		klog.V(1).Infof("neo4j driver is nil\n")
+		klog.V(6).Infof("Server.GetEntityNetwork LEAVE\n")
+		return nil, ErrDriverNil
+	}
+
+	session := (*s.driver).NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
+	defer session.Close(ctx)
+
+	links, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		myQuery := interfaces.ReplaceIndexes(`
+			MATCH (e:Entity { #entity_index#: $entity_id })-[r:CO_MENTIONED_WITH]-(o:Entity)
+			WHERE r.weight >= $min_weight
+			RETURN o.#entity_index#, o.type, o.subType, o.category, r.weight
+			ORDER BY r.weight DESC
+			LIMIT $limit`)
+		result, err := tx.Run(ctx, myQuery, map[string]any{
+			"entity_id":  entityName,
+			"min_weight": minWeight,
+			"limit":      limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		links := make([]EntityLink, 0)
+		for result.Next(ctx) {
+			values := result.Record().Values
+			links = append(links, EntityLink{
+				EntityId: values[0].(string),
+				Type:     values[1].(string),
+				SubType:  values[2].(string),
+				Category: values[3].(string),
+				Weight:   values[4].(int64),
+			})
+		}
+
+		return links, result.Err()
+	})
+	if err != nil {
+		klog.V(1).Infof("ExecuteRead failed. Err: %v\n", err)
+		klog.V(6).Infof("Server.GetEntityNetwork LEAVE\n")
+		return nil, err
+	}
+
+	klog.V(4).Infof("Server.GetEntityNetwork Succeeded\n")
+	klog.V(6).Infof("Server.GetEntityNetwork LEAVE\n")
+
+	return links.([]EntityLink), nil
+}
+
+// GetConversationsByDuration returns conversations that started at or after since, ranked by the
+// time between their first and last message. order is OrderAscending or OrderDescending and
+// conversations shorter than minDuration are skipped. Conversations that have not completed are
+// included and flagged as InProgress.
+func (s *Server) GetConversationsByDuration(ctx context.Context, order string, limit int, since time.Time, minDuration time.Duration) ([]ConversationDuration, error) {
+	klog.V(6).Infof("Server.GetConversationsByDuration ENTER\n")
+
+	if (order != OrderAscending && order != OrderDescending) || limit <= 0 {
+		klog.V(1).Infof("order or limit is invalid\n")
+		klog.V(6).Infof("Server.GetConversationsByDuration LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	if s.driver == nil {
+		klog.V(1).Infof("neo4j driver is nil\n")
+		klog.V(6).Infof("Server.GetConversationsByDuration LEAVE\n")
+		return nil, ErrDriverNil
+	}
+
+	session := (*s.driver).NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
+	defer session.Close(ctx)
+
+	conversations, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// order is validated above, it can't be passed as a parameter
+		myQuery := interfaces.ReplaceIndexes(fmt.Sprintf(`
+			MATCH (c:Conversation)
+			WHERE c.firstMessageTime >= $since AND c.lastMessageTime - c.firstMessageTime >= $min_duration
+			RETURN c.#conversation_index#, c.firstMessageTime, c.lastMessageTime, coalesce(c.completed, false)
+			ORDER BY c.lastMessageTime - c.firstMessageTime %s
+			LIMIT $limit`, order))
+		result, err := tx.Run(ctx, myQuery, map[string]any{
+			"since":        since.UnixMilli(),
+			"min_duration": minDuration.Milliseconds(),
+			"limit":        limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		conversations := make([]ConversationDuration, 0)
+		for result.Next(ctx) {
+			values := result.Record().Values
+			first := time.UnixMilli(values[1].(int64))
+			last := time.UnixMilli(values[2].(int64))
+
+			conversations = append(conversations, ConversationDuration{
+				ConversationId: values[0].(string),
+				FirstMessage:   first,
+				LastMessage:    last,
+				Duration:       last.Sub(first),
+				InProgress:     !values[3].(bool),
+			})
+		}
+
+		return conversations, result.Err()
+	})
+	if err != nil {
+		klog.V(1).Infof("ExecuteRead failed. Err: %v\n", err)
+		klog.V(6).Infof("Server.GetConversationsByDuration LEAVE\n")
+		return nil, err
+	}
+
+	klog.V(4).Infof("Server.GetConversationsByDuration Succeeded\n")
+	klog.V(6).Infof("Server.GetConversationsByDuration LEAVE\n")
+
+	return conversations.([]ConversationDuration), nil
+}
+
+// GetConversationsByParticipantOverlap returns other conversations sharing at least minShared
+// participants with convID, ranked by the number of shared participants. Speakers without a
+// userId are skipped, otherwise every conversation with an anonymous speaker would overlap
+func (s *Server) GetConversationsByParticipantOverlap(ctx context.Context, convID string, minShared int, limit int) ([]ConversationOverlap, error) {
+	klog.V(6).Infof("Server.GetConversationsByParticipantOverlap ENTER\n")
+
+	if len(convID) == 0 || minShared <= 0 || limit <= 0 {
+		klog.V(1).Infof("convID, minShared or limit is invalid\n")
+		klog.V(6).Infof("Server.GetConversationsByParticipantOverlap LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	if s.driver == nil {
+		klog.V(1).Infof("neo4j driver is nil\n")
+		klog.V(6).Infof("Server.GetConversationsByParticipantOverlap LEAVE\n")
+		return nil, ErrDriverNil
+	}
+
+	session := (*s.driver).NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
+	defer session.Close(ctx)
+
+	overlaps, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		myQuery := interfaces.ReplaceIndexes(`
+			MATCH (c:Conversation { #conversation_index#: $conversation_id })-[:MESSAGES]-(:Message)-[:SPOKE]-(u:User)
+			WITH DISTINCT u
+			WHERE u.#user_index# <> ''
+			MATCH (u)-[:SPOKE]-(:Message)-[:MESSAGES]-(o:Conversation)
+			WHERE o.#conversation_index# <> $conversation_id
+			WITH o, count(DISTINCT u) AS shared, collect(DISTINCT u.#user_index#) AS participants
+			WHERE shared >= $min_shared
+			RETURN o.#conversation_index#, shared, participants
+			ORDER BY shared DESC
+			LIMIT $limit`)
+		result, err := tx.Run(ctx, myQuery, map[string]any{
+			"conversation_id": convID,
+			"min_shared":      minShared,
+			"limit":           limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		overlaps := make([]ConversationOverlap, 0)
+		for result.Next(ctx) {
+			values := result.Record().Values
+
+			participants := make([]string, 0)
+			for _, participant := range values[2].([]any) {
+				participants = append(participants, participant.(string))
+			}
+
+			overlaps = append(overlaps, ConversationOverlap{
+				ConversationId: values[0].(string),
+				SharedCount:    values[1].(int64),
+				Participants:   participants,
+			})
+		}
+
+		return overlaps, result.Err()
+	})
+	if err != nil {
+		klog.V(1).Infof("ExecuteRead failed. Err: %v\n", err)
+		klog.V(6).Infof("Server.GetConversationsByParticipantOverlap LEAVE\n")
+		return nil, err
+	}
+
+	klog.V(4).Infof("Server.GetConversationsByParticipantOverlap Succeeded\n")
+	klog.V(6).Infof("Server.GetConversationsByParticipantOverlap LEAVE\n")
+
+	return overlaps.([]ConversationOverlap), nil
+}
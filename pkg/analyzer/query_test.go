@@ -0,0 +1,205 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	fake "github.com/dvonthenen/enterprise-reference-implementation/pkg/internal/fake"
+)
+
+// newTestServer answers every statement with records, the returned session holds what was run
+func newTestServer(records ...*neo4j.Record) (*Server, *fake.Session) {
+	session := &fake.Session{
+		OnRun: func(cypher string, params map[string]any) ([]*neo4j.Record, error) {
+			return records, nil
+		},
+	}
+	var driver neo4j.DriverWithContext = &fake.Driver{Session: session}
+	return &Server{driver: &driver}, session
+}
+
+// normalize collapses whitespace so statements can be matched against
+func normalize(cypher string) string {
+	return strings.Join(strings.Fields(cypher), " ")
+}
+
+func TestGetEntityNetwork(t *testing.T) {
+	server, session := newTestServer(
+		fake.NewRecord("o.entityId", "org_c_x", "o.type", "org", "o.subType", "c", "o.category", "x", "r.weight", int64(5)),
+		fake.NewRecord("o.entityId", "place_e_x", "o.type", "place", "o.subType", "e", "o.category", "x", "r.weight", int64(3)),
+	)
+
+	links, err := server.GetEntityNetwork(context.Background(), "person_a_x", 2, 10)
+	if err != nil {
+		t.Fatalf("GetEntityNetwork() err = %v", err)
+	}
+	want := []EntityLink{
+		{EntityId: "org_c_x", Type: "org", SubType: "c", Category: "x", Weight: 5},
+		{EntityId: "place_e_x", Type: "place", SubType: "e", Category: "x", Weight: 3},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("GetEntityNetwork() = %v, want %v", links, want)
+	}
+
+	statements := session.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("ran %d statements, want 1", len(statements))
+	}
+	wantParams := map[string]any{"entity_id": "person_a_x", "min_weight": 2, "limit": 10}
+	if !reflect.DeepEqual(statements[0].Parameters, wantParams) {
+		t.Errorf("params = %v, want %v", statements[0].Parameters, wantParams)
+	}
+	cypher := normalize(statements[0].Cypher)
+	for _, clause := range []string{
+		"MATCH (e:Entity { entityId: $entity_id })-[r:CO_MENTIONED_WITH]-(o:Entity)",
+		"WHERE r.weight >= $min_weight",
+		"ORDER BY r.weight DESC",
+		"LIMIT $limit",
+	} {
+		if !strings.Contains(cypher, clause) {
+			t.Errorf("statement is missing %q:\n%s", clause, cypher)
+		}
+	}
+
+	_, err = server.GetEntityNetwork(context.Background(), "", 1, 10)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetEntityNetwork() err = %v, want %v", err, ErrInvalidInput)
+	}
+
+	_, err = (&Server{}).GetEntityNetwork(context.Background(), "person_a_x", 1, 10)
+	if !errors.Is(err, ErrDriverNil) {
+		t.Errorf("GetEntityNetwork() err = %v, want %v", err, ErrDriverNil)
+	}
+}
+
+func TestGetConversationsByDuration(t *testing.T) {
+	base := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	server, session := newTestServer(
+		fake.NewRecord("c.conversationId", "long", "c.firstMessageTime", base.UnixMilli(), "c.lastMessageTime", base.Add(time.Hour).UnixMilli(), "coalesce(c.completed, false)", false),
+		fake.NewRecord("c.conversationId", "short", "c.firstMessageTime", base.UnixMilli(), "c.lastMessageTime", base.Add(time.Minute).UnixMilli(), "coalesce(c.completed, false)", true),
+	)
+
+	conversations, err := server.GetConversationsByDuration(context.Background(), OrderDescending, 5, base, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("GetConversationsByDuration() err = %v", err)
+	}
+	want := []ConversationDuration{
+		{ConversationId: "long", FirstMessage: base, LastMessage: base.Add(time.Hour), Duration: time.Hour, InProgress: true},
+		{ConversationId: "short", FirstMessage: base, LastMessage: base.Add(time.Minute), Duration: time.Minute, InProgress: false},
+	}
+	if len(conversations) != len(want) {
+		t.Fatalf("GetConversationsByDuration() = %v, want %v", conversations, want)
+	}
+	for i := range want {
+		got := conversations[i]
+		if got.ConversationId != want[i].ConversationId || !got.FirstMessage.Equal(want[i].FirstMessage) ||
+			!got.LastMessage.Equal(want[i].LastMessage) || got.Duration != want[i].Duration || got.InProgress != want[i].InProgress {
+			t.Errorf("conversation %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	// order can't be a parameter, it is written into the statement
+	for _, order := range []string{OrderAscending, OrderDescending} {
+		if _, err := server.GetConversationsByDuration(context.Background(), order, 5, base, 10*time.Minute); err != nil {
+			t.Fatalf("GetConversationsByDuration(%s) err = %v", order, err)
+		}
+	}
+	statements := session.Statements()
+	if len(statements) != 3 {
+		t.Fatalf("ran %d statements, want 3", len(statements))
+	}
+	wantParams := map[string]any{
+		"since":        base.UnixMilli(),
+		"min_duration": (10 * time.Minute).Milliseconds(),
+		"limit":        5,
+	}
+	for i, order := range []string{OrderDescending, OrderAscending, OrderDescending} {
+		if !reflect.DeepEqual(statements[i].Parameters, wantParams) {
+			t.Errorf("statement %d params = %v, want %v", i, statements[i].Parameters, wantParams)
+		}
+		cypher := normalize(statements[i].Cypher)
+		for _, clause := range []string{
+			"WHERE c.firstMessageTime >= $since AND c.lastMessageTime - c.firstMessageTime >= $min_duration",
+			"RETURN c.conversationId, c.firstMessageTime, c.lastMessageTime, coalesce(c.completed, false)",
+			"ORDER BY c.lastMessageTime - c.firstMessageTime " + order + " LIMIT $limit",
+		} {
+			if !strings.Contains(cypher, clause) {
+				t.Errorf("statement %d is missing %q:\n%s", i, clause, cypher)
+			}
+		}
+	}
+
+	_, err = server.GetConversationsByDuration(context.Background(), "sideways", 10, base, 0)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetConversationsByDuration() err = %v, want %v", err, ErrInvalidInput)
+	}
+	_, err = server.GetConversationsByDuration(context.Background(), OrderAscending, 0, base, 0)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetConversationsByDuration() err = %v, want %v", err, ErrInvalidInput)
+	}
+	if len(session.Statements()) != 3 {
+		t.Errorf("invalid input ran a statement")
+	}
+}
+
+func TestGetConversationsByParticipantOverlap(t *testing.T) {
+	server, session := newTestServer(
+		fake.NewRecord("o.conversationId", "conv5", "shared", int64(3), "participants", []any{"alice", "bob", "carol"}),
+		fake.NewRecord("o.conversationId", "conv2", "shared", int64(2), "participants", []any{"alice", "bob"}),
+	)
+
+	overlaps, err := server.GetConversationsByParticipantOverlap(context.Background(), "conv1", 2, 10)
+	if err != nil {
+		t.Fatalf("GetConversationsByParticipantOverlap() err = %v", err)
+	}
+	want := []ConversationOverlap{
+		{ConversationId: "conv5", SharedCount: 3, Participants: []string{"alice", "bob", "carol"}},
+		{ConversationId: "conv2", SharedCount: 2, Participants: []string{"alice", "bob"}},
+	}
+	if !reflect.DeepEqual(overlaps, want) {
+		t.Errorf("GetConversationsByParticipantOverlap() = %v, want %v", overlaps, want)
+	}
+
+	statements := session.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("ran %d statements, want 1", len(statements))
+	}
+	wantParams := map[string]any{"conversation_id": "conv1", "min_shared": 2, "limit": 10}
+	if !reflect.DeepEqual(statements[0].Parameters, wantParams) {
+		t.Errorf("params = %v, want %v", statements[0].Parameters, wantParams)
+	}
+
+	// speakers without a userId are dropped before looking for other conversations
+	cypher := normalize(statements[0].Cypher)
+	for _, clause := range []string{
+		"MATCH (c:Conversation { conversationId: $conversation_id })-[:MESSAGES]-(:Message)-[:SPOKE]-(u:User) " +
+			"WITH DISTINCT u WHERE u.userId <> '' " +
+			"MATCH (u)-[:SPOKE]-(:Message)-[:MESSAGES]-(o:Conversation) " +
+			"WHERE o.conversationId <> $conversation_id",
+		"WITH o, count(DISTINCT u) AS shared, collect(DISTINCT u.userId) AS participants WHERE shared >= $min_shared",
+		"ORDER BY shared DESC LIMIT $limit",
+	} {
+		if !strings.Contains(cypher, clause) {
+			t.Errorf("statement is missing %q:\n%s", clause, cypher)
+		}
+	}
+
+	_, err = server.GetConversationsByParticipantOverlap(context.Background(), "conv1", 0, 10)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetConversationsByParticipantOverlap() err = %v, want %v", err, ErrInvalidInput)
+	}
+	_, err = server.GetConversationsByParticipantOverlap(context.Background(), "", 1, 10)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetConversationsByParticipantOverlap() err = %v, want %v", err, ErrInvalidInput)
+	}
+}
@@ -6,6 +6,7 @@ package analyzer
 import (
 	"context"
 	"os"
+	"time"
 
 	rabbit "github.com/dvonthenen/rabbitmq-manager/pkg"
 	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
@@ -123,6 +124,8 @@ func (s *Server) Start() error {
 		Driver:        s.driver,
 		RabbitManager: s.rabbitMgr,
 		SymblClient:   s.symblClient,
+		Cipher:        s.options.Cipher,
+		Journal:       s.options.Journal,
 	})
 	err := notificationManager.Init()
 	if err != nil {
@@ -131,6 +134,9 @@ func (s *Server) Start() error {
 		return err
 	}
 
+	// housekeeping
+	s.notificationMgr = notificationManager
+
 	klog.V(4).Infof("Server.Start Succeeded\n")
 	klog.V(6).Infof("Server.Start LEAVE\n")
 
@@ -221,6 +227,62 @@ func (s *Server) RebuildMessageBus() error {
 	return nil
 }
 
+// PauseConversation quarantines a single conversation in the analyzer. While paused, the analyzer
+// acks and skips all insight messages for this conversationId. Other conversations continue to be
+// processed. This only stops analyzer-side processing, the Dataminer keeps writing the
+// conversation's messages, topics, trackers, entities and insights into neo4j and publishing them.
+// Messages skipped while paused are not replayed on ResumeConversation.
+func (s *Server) PauseConversation(conversationId string) error {
+	if len(conversationId) == 0 {
+		klog.V(1).Infof("conversationId is empty\n")
+		return ErrInvalidInput
+	}
+	if s.notificationMgr == nil {
+		klog.V(1).Infof("notificationMgr is nil\n")
+		return ErrNotificationMgrNil
+	}
+
+	s.notificationMgr.PauseConversation(conversationId)
+	klog.V(3).Infof("PauseConversation(%s) Succeeded\n", conversationId)
+
+	return nil
+}
+
+// ResumeConversation resumes analyzer processing of a paused conversation
+func (s *Server) ResumeConversation(conversationId string) error {
+	if len(conversationId) == 0 {
+		klog.V(1).Infof("conversationId is empty\n")
+		return ErrInvalidInput
+	}
+	if s.notificationMgr == nil {
+		klog.V(1).Infof("notificationMgr is nil\n")
+		return ErrNotificationMgrNil
+	}
+
+	s.notificationMgr.ResumeConversation(conversationId)
+	klog.V(3).Infof("ResumeConversation(%s) Succeeded\n", conversationId)
+
+	return nil
+}
+
+// SignalBackpressure tells all Dataminer proxies to pause and/or delay publishing conversation
+// insights. Call this when the write path is saturated and again with (false, 0) to clear it.
+func (s *Server) SignalBackpressure(pause bool, delay time.Duration) error {
+	if s.notificationMgr == nil {
+		klog.V(1).Infof("notificationMgr is nil\n")
+		return ErrNotificationMgrNil
+	}
+
+	err := s.notificationMgr.SignalBackpressure(pause, delay)
+	if err != nil {
+		klog.V(1).Infof("SignalBackpressure failed. Err: %v\n", err)
+		return err
+	}
+	klog.V(3).Infof("SignalBackpressure(%t, %v) Succeeded\n", pause, delay)
+
+	return nil
+}
+
 func (s *Server) Stop() error {
 	klog.V(6).Infof("Server.Stop ENTER\n")
 
@@ -4,11 +4,15 @@
 package analyzer
 
 import (
+	"time"
+
 	rabbit "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
 	symbl "github.com/dvonthenen/symbl-go-sdk/pkg/client"
 	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
 	handlers "github.com/dvonthenen/enterprise-reference-implementation/pkg/analyzer/handlers"
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	journal "github.com/dvonthenen/enterprise-reference-implementation/pkg/journal"
 )
 
 // Credentials is the input needed to login to neo4j
@@ -25,6 +29,38 @@ type ServerOptions struct {
 	BindAddress string
 	BindPort    int
 	RabbitURI   string
+
+	// field level encryption, used to decrypt properties written by the dataminer
+	Cipher interfaces.Cipher
+
+	// optional log of all executed Cypher writes for replay
+	Journal *journal.Journal
+}
+
+// EntityLink is an entity co-mentioned with the requested entity
+type EntityLink struct {
+	EntityId string
+	Type     string
+	SubType  string
+	Category string
+	Weight   int64
+}
+
+// ConversationDuration is a conversation ranked by duration. InProgress conversations have
+// not been torn down yet so LastMessage and Duration are as of the latest message seen
+type ConversationDuration struct {
+	ConversationId string
+	FirstMessage   time.Time
+	LastMessage    time.Time
+	Duration       time.Duration
+	InProgress     bool
+}
+
+// ConversationOverlap is a conversation sharing participants with the requested conversation
+type ConversationOverlap struct {
+	ConversationId string
+	SharedCount    int64
+	Participants   []string // userIds of the shared participants
 }
 
 type Server struct {
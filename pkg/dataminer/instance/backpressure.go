@@ -0,0 +1,41 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package instance
+
+import (
+	"encoding/json"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	routing "github.com/dvonthenen/enterprise-reference-implementation/pkg/dataminer/routing"
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+)
+
+func NewBackpressureHandler(messageMgr *routing.MessageHandler) *BackpressureHandler {
+	handler := &BackpressureHandler{
+		messageMgr: messageMgr,
+	}
+	return handler
+}
+
+func (bh *BackpressureHandler) ProcessMessage(byData []byte) error {
+	klog.V(5).Infof(" [x] %s\n", string(byData))
+
+	var bm interfaces.BackpressureMessage
+	err := json.Unmarshal(byData, &bm)
+	if err != nil {
+		klog.V(1).Infof("[Backpressure] json.Unmarshal failed. Err: %v\n", err)
+		return err
+	}
+
+	if bm.Type != interfaces.ControlMessageTypeBackpressure {
+		klog.V(1).Infof("[Backpressure] Unknown Message Type: %s\n", bm.Type)
+		return ErrUnknownControlType
+	}
+
+	bh.messageMgr.SetBackpressure(bm.Pause, time.Duration(bm.DelayMs)*time.Millisecond, time.Duration(bm.TtlMs)*time.Millisecond)
+
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	sdkinterfaces "github.com/dvonthenen/symbl-go-sdk/pkg/api/streaming/v1/interfaces"
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	routing "github.com/dvonthenen/enterprise-reference-implementation/pkg/dataminer/routing"
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	fake "github.com/dvonthenen/enterprise-reference-implementation/pkg/internal/fake"
+	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
+)
+
+func newTestMessageHandler(t *testing.T, maxPause time.Duration) (*routing.MessageHandler, *fake.Manager) {
+	fakeManager := &fake.Manager{Published: make(chan fake.Message, 10)}
+	var manager rabbitinterfaces.Manager = fakeManager
+	var session neo4j.SessionWithContext = &fake.Session{}
+
+	mh, err := routing.NewHandler(routing.MessageHandlerOptions{
+		ConversationId:           "conv1",
+		Neo4jMgr:                 &session,
+		RabbitMgr:                &manager,
+		BackpressurePollInterval: time.Millisecond,
+		BackpressureMaxPause:     maxPause,
+	})
+	if err != nil {
+		t.Fatalf("NewHandler() err = %v", err)
+	}
+	if err := mh.Init(); err != nil {
+		t.Fatalf("Init() err = %v", err)
+	}
+	t.Cleanup(func() { mh.Teardown() })
+
+	return mh, fakeManager
+}
+
+func signal(t *testing.T, bh *BackpressureHandler, pause bool, delayMs, ttlMs int64) {
+	msg := fmt.Sprintf(`{"type": "backpressure", "pause": %t, "delayMs": %d, "ttlMs": %d}`, pause, delayMs, ttlMs)
+	if err := bh.ProcessMessage([]byte(msg)); err != nil {
+		t.Fatalf("ProcessMessage() err = %v", err)
+	}
+}
+
+// queue publishes a message, the caller must not be held by backpressure
+func queue(t *testing.T, mh *routing.MessageHandler) {
+	start := time.Now()
+	if err := mh.InitializedConversation(&sdkinterfaces.InitializationMessage{}); err != nil {
+		t.Fatalf("InitializedConversation() err = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("InitializedConversation() blocked for %v", elapsed)
+	}
+}
+
+func waitPublished(t *testing.T, fakeManager *fake.Manager, timeout time.Duration) (fake.Message, bool) {
+	select {
+	case msg := <-fakeManager.Published:
+		return msg, true
+	case <-time.After(timeout):
+		return fake.Message{}, false
+	}
+}
+
+func TestBackpressurePause(t *testing.T) {
+	mh, fakeManager := newTestMessageHandler(t, time.Minute)
+	bh := NewBackpressureHandler(mh)
+
+	signal(t, bh, true, 0, 60000)
+	queue(t, mh)
+
+	if msg, ok := waitPublished(t, fakeManager, 50*time.Millisecond); ok {
+		t.Fatalf("published %s while paused", msg.Name)
+	}
+
+	signal(t, bh, false, 0, 60000)
+	if _, ok := waitPublished(t, fakeManager, time.Second); !ok {
+		t.Fatalf("not published after the signal was cleared")
+	}
+}
+
+func TestBackpressureLostClear(t *testing.T) {
+	mh, fakeManager := newTestMessageHandler(t, time.Minute)
+	bh := NewBackpressureHandler(mh)
+
+	// refreshes keep the pause alive past its ttl
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		signal(t, bh, true, 0, 40)
+		if i == 0 {
+			queue(t, mh)
+		}
+		if msg, ok := waitPublished(t, fakeManager, 10*time.Millisecond); ok {
+			t.Fatalf("published %s while the pause was being refreshed", msg.Name)
+		}
+	}
+
+	// the clear never arrives, the proxy recovers once the ttl runs out
+	if _, ok := waitPublished(t, fakeManager, time.Second); !ok {
+		t.Fatalf("not published after the signal expired")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("published after %v, want well before the max pause", elapsed)
+	}
+
+	// and is no longer throttled
+	queue(t, mh)
+	if _, ok := waitPublished(t, fakeManager, 20*time.Millisecond); !ok {
+		t.Errorf("not published promptly after the signal expired")
+	}
+}
+
+func TestBackpressureMaxPause(t *testing.T) {
+	mh, fakeManager := newTestMessageHandler(t, 60*time.Millisecond)
+	bh := NewBackpressureHandler(mh)
+
+	start := time.Now()
+	signal(t, bh, true, 0, 60000)
+	for i := 0; i < 3; i++ {
+		queue(t, mh)
+	}
+
+	// the bound covers the whole pause, a refresh doesn't restart it
+	time.Sleep(30 * time.Millisecond)
+	signal(t, bh, true, 0, 60000)
+
+	for i := 0; i < 3; i++ {
+		if _, ok := waitPublished(t, fakeManager, time.Second); !ok {
+			t.Fatalf("message %d not published after the max pause", i+1)
+		}
+		if i == 0 {
+			if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+				t.Errorf("published after %v, want at least 60ms", elapsed)
+			}
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("3 messages published after %v, the max pause applied per message", elapsed)
+	}
+}
+
+func TestBackpressureDelay(t *testing.T) {
+	mh, fakeManager := newTestMessageHandler(t, time.Minute)
+	bh := NewBackpressureHandler(mh)
+
+	signal(t, bh, false, 30, 60000)
+
+	start := time.Now()
+	queue(t, mh)
+	if _, ok := waitPublished(t, fakeManager, time.Second); !ok {
+		t.Fatalf("not published")
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("published after %v, want at least 30ms", elapsed)
+	}
+}
+
+func TestBackpressureTeardownFlushes(t *testing.T) {
+	mh, fakeManager := newTestMessageHandler(t, 30*time.Millisecond)
+	bh := NewBackpressureHandler(mh)
+
+	signal(t, bh, true, 0, 60000)
+	queue(t, mh)
+	queue(t, mh)
+
+	// teardown isn't throttled but waits for what was queued before it
+	tm := &sdkinterfaces.TeardownMessage{}
+	if err := mh.TeardownConversation(tm); err != nil {
+		t.Fatalf("TeardownConversation() err = %v", err)
+	}
+
+	messages := fakeManager.Messages()
+	want := []string{
+		interfaces.RabbitExchangeConversationInit,
+		interfaces.RabbitExchangeConversationInit,
+		interfaces.RabbitExchangeConversationTeardown,
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("published %d messages, want %d", len(messages), len(want))
+	}
+	for i, msg := range messages {
+		if msg.Name != want[i] {
+			t.Errorf("message %d = %s, want %s", i, msg.Name, want[i])
+		}
+	}
+}
+
+func TestBackpressureUnknownType(t *testing.T) {
+	bh := NewBackpressureHandler(nil)
+
+	err := bh.ProcessMessage([]byte(`{"type": "other", "pause": true}`))
+	if !errors.Is(err, ErrUnknownControlType) {
+		t.Errorf("ProcessMessage() err = %v, want %v", err, ErrUnknownControlType)
+	}
+}
@@ -31,4 +31,7 @@ var (
 
 	// ErrUnknownNotifyType unknown notify message type
 	ErrUnknownNotifyType = errors.New("unknown notify message type")
+
+	// ErrUnknownControlType unknown control message type
+	ErrUnknownControlType = errors.New("unknown control message type")
 )
@@ -18,6 +18,7 @@ import (
 	klog "k8s.io/klog/v2"
 
 	routing "github.com/dvonthenen/enterprise-reference-implementation/pkg/dataminer/routing"
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
 )
 
 func New(options ProxyOptions) *Proxy {
@@ -80,6 +81,10 @@ func (p *Proxy) Init() error {
 		ConversationId: p.options.ConversationId,
 		Neo4jMgr:       p.neo4jMgr,
 		RabbitMgr:      rabbitMgr,
+
+		Cipher:              p.options.Cipher,
+		EncryptedProperties: p.options.EncryptedProperties,
+		Journal:             p.options.Journal,
 	}
 	messageMgr, err := routing.NewHandler(options)
 	if err != nil {
@@ -96,6 +101,28 @@ func (p *Proxy) Init() error {
 		return err
 	}
 
+	/*
+		Create Backpressure Channel Subscriber
+
+		The Analyzer component signals on this channel when it wants all proxies to slow
+		down or pause publishing conversation insights
+	*/
+	var backpressureHandler rabbitinterfaces.RabbitMessageHandler
+	backpressureHandler = NewBackpressureHandler(messageMgr)
+
+	_, err = (*rabbitMgr).CreateSubscriber(rabbitinterfaces.SubscriberOptions{
+		Name:        interfaces.RabbitExchangeBackpressure,
+		Type:        rabbitinterfaces.ExchangeTypeFanout,
+		AutoDeleted: true,
+		IfUnused:    true,
+		Handler:     &backpressureHandler,
+	})
+	if err != nil {
+		klog.V(1).Infof("CreateSubscriber %s failed. Err: %v\n", interfaces.RabbitExchangeBackpressure, err)
+		klog.V(6).Infof("Proxy.Init LEAVE\n")
+		return err
+	}
+
 	// init rabbit
 	err = (*rabbitMgr).Init()
 	if err != nil {
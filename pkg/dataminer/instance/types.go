@@ -13,6 +13,8 @@ import (
 	sse "github.com/r3labs/sse/v2"
 
 	routing "github.com/dvonthenen/enterprise-reference-implementation/pkg/dataminer/routing"
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	journal "github.com/dvonthenen/enterprise-reference-implementation/pkg/journal"
 )
 
 type ProxyOptions struct {
@@ -33,6 +35,18 @@ type ProxyOptions struct {
 	// objects
 	Neo4jMgr *neo4j.SessionWithContext
 	ProxyMgr *wsinterfaces.ManageCallback
+
+	// field level encryption
+	Cipher              interfaces.Cipher
+	EncryptedProperties []string
+
+	// cypher journal
+	Journal *journal.Journal
+}
+
+// BackpressureHandler applies the Analyzer backpressure signal to this instance's message router
+type BackpressureHandler struct {
+	messageMgr *routing.MessageHandler
 }
 
 type Proxy struct {
@@ -5,6 +5,22 @@ package routing
 
 import (
 	"errors"
+	"time"
+)
+
+const (
+	// upper bound on how long a single pause from the analyzer holds publishing, refreshes of the
+	// same pause don't extend it
+	DefaultBackpressureMaxPause time.Duration = 30 * time.Second
+
+	// how often a paused proxy checks to see if it has been resumed
+	DefaultBackpressurePollInterval time.Duration = 100 * time.Millisecond
+
+	// how long a backpressure signal holds without a refresh when the message doesn't carry a TTL
+	DefaultBackpressureTTL time.Duration = 15 * time.Second
+
+	// messages waiting to be published before the caller blocks
+	DefaultPublishQueueSize int = 1000
 )
 
 var (
@@ -16,6 +16,7 @@ import (
 	klog "k8s.io/klog/v2"
 
 	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	journal "github.com/dvonthenen/enterprise-reference-implementation/pkg/journal"
 )
 
 func NewHandler(options MessageHandlerOptions) (*MessageHandler, error) {
@@ -24,10 +25,41 @@ func NewHandler(options MessageHandlerOptions) (*MessageHandler, error) {
 		return nil, ErrInvalidInput
 	}
 
+	encrypted := make(map[string]bool)
+	for _, name := range options.EncryptedProperties {
+		switch name {
+		case interfaces.DatabasePropertyContent, interfaces.DatabasePropertyRaw:
+			encrypted[name] = true
+		default:
+			klog.V(1).Infof("EncryptedProperties (%s) is not supported\n", name)
+			return nil, ErrInvalidInput
+		}
+	}
+	if len(encrypted) > 0 && options.Cipher == nil {
+		klog.V(1).Infof("EncryptedProperties set but Cipher is nil\n")
+		return nil, ErrInvalidInput
+	}
+
+	if options.BackpressurePollInterval <= 0 {
+		options.BackpressurePollInterval = DefaultBackpressurePollInterval
+	}
+	if options.BackpressureMaxPause <= 0 {
+		options.BackpressureMaxPause = DefaultBackpressureMaxPause
+	}
+	if options.BackpressureTTL <= 0 {
+		options.BackpressureTTL = DefaultBackpressureTTL
+	}
+
 	mh := &MessageHandler{
-		conversationId: options.ConversationId,
-		neo4jMgr:       options.Neo4jMgr,
-		rabbitMgr:      options.RabbitMgr,
+		conversationId:           options.ConversationId,
+		neo4jMgr:                 options.Neo4jMgr,
+		rabbitMgr:                options.RabbitMgr,
+		cipher:                   options.Cipher,
+		encrypted:                encrypted,
+		journal:                  options.Journal,
+		backpressurePollInterval: options.BackpressurePollInterval,
+		backpressureMaxPause:     options.BackpressureMaxPause,
+		backpressureTTL:          options.BackpressureTTL,
 	}
 	return mh, nil
 }
@@ -48,7 +80,7 @@ func (mh *MessageHandler) Init() error {
 	defer cancel()
 
 	// neo4j create conversation object
-	_, err = (*mh.neo4jMgr).ExecuteWrite(ctx,
+	_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 		func(tx neo4j.ManagedTransaction) (any, error) {
 			createConversationQuery := interfaces.ReplaceIndexes(`
 				MERGE (c:Conversation { #conversation_index#: $conversation_id })
@@ -69,6 +101,13 @@ func (mh *MessageHandler) Init() error {
 		return err
 	}
 
+	// publish to the analyzer in the background
+	mh.publishMu.Lock()
+	mh.publishChan = make(chan *publishRequest, DefaultPublishQueueSize)
+	mh.publishStop = make(chan struct{})
+	go mh.publishLoop(mh.publishChan, mh.publishStop)
+	mh.publishMu.Unlock()
+
 	klog.V(4).Infof("MessageHandler.Init Succeeded\n")
 	klog.V(6).Infof("MessageHandler.Init LEAVE\n")
 
@@ -155,12 +194,119 @@ func (mh *MessageHandler) setupRabbitChannels() error {
 
 func (mh *MessageHandler) Teardown() error {
 	klog.V(6).Infof("MessageHandler.Teardown ENTER\n")
-	klog.V(4).Infof("MessageHandler.Teardown Succeeded\n") // This Teardown() currently is a NOOP
+
+	// flush anything still queued for the analyzer
+	mh.publishMu.Lock()
+	publishStop := mh.publishStop
+	if mh.publishChan != nil {
+		close(mh.publishChan)
+		mh.publishChan = nil
+	}
+	mh.publishMu.Unlock()
+
+	if publishStop != nil {
+		<-publishStop
+	}
+
+	klog.V(4).Infof("MessageHandler.Teardown Succeeded\n")
 	klog.V(6).Infof("MessageHandler.Teardown LEAVE\n")
 
 	return nil
 }
 
+// SetBackpressure applies the signal from the Analyzer component. When paused, publishing to the
+// Analyzer is held until resumed, for at most BackpressureMaxPause per pause. The delay is applied
+// to every publish while set. The signal is dropped if it isn't refreshed within ttl (zero uses
+// BackpressureTTL), so a proxy that misses the clear recovers on its own.
+func (mh *MessageHandler) SetBackpressure(pause bool, delay time.Duration, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = mh.backpressureTTL
+	}
+	now := time.Now()
+
+	mh.mu.Lock()
+	if pause && !(mh.backpressurePause && now.Before(mh.backpressureExpires)) {
+		mh.backpressurePauseStart = now
+	}
+	mh.backpressurePause = pause
+	mh.backpressureDelay = delay
+	mh.backpressureExpires = now.Add(ttl)
+	mh.mu.Unlock()
+
+	klog.V(3).Infof("SetBackpressure pause: %t, delay: %v, ttl: %v\n", pause, delay, ttl)
+}
+
+func (mh *MessageHandler) throttle() {
+	for {
+		mh.mu.Lock()
+		now := time.Now()
+		active := now.Before(mh.backpressureExpires)
+		paused := active && mh.backpressurePause
+		exceeded := paused && now.Sub(mh.backpressurePauseStart) >= mh.backpressureMaxPause
+		delay := mh.backpressureDelay
+		mh.mu.Unlock()
+
+		if paused && !exceeded {
+			time.Sleep(mh.backpressurePollInterval)
+			continue
+		}
+		if exceeded {
+			klog.V(3).Infof("Backpressure pause exceeded %v. Publishing anyway.\n", mh.backpressureMaxPause)
+		}
+
+		if active && delay > 0 {
+			time.Sleep(delay)
+		}
+		return
+	}
+}
+
+// publish queues data for the named exchange. When wait is set, it returns once the message
+// was published instead of when it was queued.
+func (mh *MessageHandler) publish(name string, data []byte, throttle bool, wait bool) error {
+	request := &publishRequest{
+		name:     name,
+		data:     data,
+		throttle: throttle,
+	}
+	if wait {
+		request.done = make(chan error, 1)
+	}
+
+	mh.publishMu.Lock()
+	if mh.publishChan == nil {
+		mh.publishMu.Unlock()
+		klog.V(1).Infof("publish queue is not running\n")
+		return ErrChannelNotFound
+	}
+	mh.publishChan <- request
+	mh.publishMu.Unlock()
+
+	if wait {
+		return <-request.done
+	}
+	return nil
+}
+
+func (mh *MessageHandler) publishLoop(publishChan chan *publishRequest, publishStop chan struct{}) {
+	defer close(publishStop)
+
+	for request := range publishChan {
+		if request.throttle {
+			mh.throttle()
+		}
+
+		err := (*mh.rabbitMgr).PublishMessageByName(request.name, request.data)
+		if err != nil {
+			klog.V(1).Infof("PublishMessageByName(%s) failed. Err: %v\n", request.name, err)
+		}
+
+		if request.done != nil {
+			request.done <- err
+		}
+	}
+}
+
 func (mh *MessageHandler) InitializedConversation(im *sdkinterfaces.InitializationMessage) error {
 	klog.V(6).Infof("InitializedConversation ENTER\n")
 
@@ -186,9 +332,9 @@ func (mh *MessageHandler) InitializedConversation(im *sdkinterfaces.Initializati
 	klog.V(6).Infof("-------------------------------\n\n")
 
 	// rabbitmq
-	err = (*mh.rabbitMgr).PublishMessageByName(interfaces.RabbitExchangeConversationInit, data)
+	err = mh.publish(interfaces.RabbitExchangeConversationInit, data, true, false)
 	if err != nil {
-		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		klog.V(1).Infof("publish failed. Err: %v\n", err)
 		klog.V(6).Infof("InitializedConversation LEAVE\n")
 		return err
 	}
@@ -243,6 +389,14 @@ func (mh *MessageHandler) MessageResponseMessage(mr *sdkinterfaces.MessageRespon
 	klog.V(2).Infof("MessageResponseMessage:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// field level encryption
+	raw, rawKeyId, err := mh.protect(interfaces.DatabasePropertyRaw, string(data))
+	if err != nil {
+		klog.V(1).Infof("protect raw failed. Err: %v\n", err)
+		klog.V(6).Infof("MessageResponseMessage LEAVE\n")
+		return err
+	}
+
 	// write the object to the database
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -250,16 +404,25 @@ func (mh *MessageHandler) MessageResponseMessage(mr *sdkinterfaces.MessageRespon
 	// if we need to do something with them
 	// for records, message := range mr.Messages {
 	for _, message := range mr.Messages {
-		_, err := (*mh.neo4jMgr).ExecuteWrite(ctx,
+		content, contentKeyId, err := mh.protect(interfaces.DatabasePropertyContent, message.Payload.Content)
+		if err != nil {
+			klog.V(1).Infof("protect content failed. Err: %v\n", err)
+			klog.V(6).Infof("MessageResponseMessage LEAVE\n")
+			return err
+		}
+
+		_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 			func(tx neo4j.ManagedTransaction) (any, error) {
 				createMessageToPeopleQuery := interfaces.ReplaceIndexes(`
 					MATCH (c:Conversation { #conversation_index#: $conversation_id })
+					SET c.firstMessageTime = CASE WHEN c.firstMessageTime IS NULL OR $start_millis < c.firstMessageTime THEN $start_millis ELSE c.firstMessageTime END,
+						c.lastMessageTime = CASE WHEN c.lastMessageTime IS NULL OR $end_millis > c.lastMessageTime THEN $end_millis ELSE c.lastMessageTime END
 					MERGE (m:Message { #message_index#: $message_id })
 						ON CREATE SET
 							m.lastAccessed = timestamp()
 						ON MATCH SET
 							m.lastAccessed = timestamp()
-					SET m = { #message_index#: $message_id, content: $content, startTime: $start_time, endTime: $end_time, timeOffset: $time_offset, duration: $duration, sequenceNumber: $sequence_number, raw: $raw }
+					SET m = { #message_index#: $message_id, content: $content, contentKeyId: $content_key_id, startTime: $start_time, endTime: $end_time, timeOffset: $time_offset, duration: $duration, sequenceNumber: $sequence_number, raw: $raw, rawKeyId: $raw_key_id }
 					MERGE (u:User { #user_index#: $user_id })
 						ON CREATE SET
 							u.lastAccessed = timestamp()
@@ -274,16 +437,20 @@ func (mh *MessageHandler) MessageResponseMessage(mr *sdkinterfaces.MessageRespon
 				result, err := tx.Run(ctx, createMessageToPeopleQuery, map[string]any{
 					"conversation_id": mh.conversationId,
 					"message_id":      message.ID,
-					"content":         message.Payload.Content,
+					"content":         content,
+					"content_key_id":  contentKeyId,
 					"start_time":      message.Duration.StartTime,
 					"end_time":        message.Duration.EndTime,
+					"start_millis":    convertTimeToMillis(message.Duration.StartTime),
+					"end_millis":      convertTimeToMillis(message.Duration.EndTime),
 					"time_offset":     message.Duration.TimeOffset,
 					"duration":        message.Duration.Duration,
 					"sequence_number": mr.SequenceNumber,
 					"user_real_id":    message.From.ID,
 					"user_name":       message.From.Name,
 					"user_id":         message.From.UserID,
-					"raw":             string(data),
+					"raw":             raw,
+					"raw_key_id":      rawKeyId,
 				})
 				if err != nil {
 					klog.V(1).Infof("neo4j.Run failed create conversation object. Err: %v\n", err)
@@ -311,9 +478,9 @@ func (mh *MessageHandler) MessageResponseMessage(mr *sdkinterfaces.MessageRespon
 		return err
 	}
 
-	err = (*mh.rabbitMgr).PublishMessageByName(interfaces.RabbitExchangeMessage, data)
+	err = mh.publish(interfaces.RabbitExchangeMessage, data, true, false)
 	if err != nil {
-		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		klog.V(1).Infof("publish failed. Err: %v\n", err)
 		klog.V(6).Infof("InitializedConversation LEAVE\n")
 		return err
 	}
@@ -373,12 +540,20 @@ func (mh *MessageHandler) TopicResponseMessage(tr *sdkinterfaces.TopicResponse)
 	klog.V(2).Infof("TopicResponseMessage:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// field level encryption
+	raw, rawKeyId, err := mh.protect(interfaces.DatabasePropertyRaw, string(data))
+	if err != nil {
+		klog.V(1).Infof("protect raw failed. Err: %v\n", err)
+		klog.V(6).Infof("TopicResponseMessage LEAVE\n")
+		return err
+	}
+
 	// write the object to the database
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	for _, topic := range tr.Topics {
-		_, err := (*mh.neo4jMgr).ExecuteWrite(ctx,
+		_, err := journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 			func(tx neo4j.ManagedTransaction) (any, error) {
 				createTopicsQuery := interfaces.ReplaceIndexes(`
 					MATCH (c:Conversation { #conversation_index#: $conversation_id })
@@ -387,7 +562,7 @@ func (mh *MessageHandler) TopicResponseMessage(tr *sdkinterfaces.TopicResponse)
 							t.lastAccessed = timestamp()
 						ON MATCH SET
 							t.lastAccessed = timestamp()
-					SET t = { #topic_index#: $topic_id, phrases: $phrases, score: $score, type: $type, messageIndex: $symbl_message_index, rootWords: $root_words, raw: $raw }
+					SET t = { #topic_index#: $topic_id, phrases: $phrases, score: $score, type: $type, messageIndex: $symbl_message_index, rootWords: $root_words, raw: $raw, rawKeyId: $raw_key_id }
 					MERGE (c)-[x:TOPICS { #conversation_index#: $conversation_id }]-(t)
 					SET x = { #conversation_index#: $conversation_id }
 					`)
@@ -399,7 +574,8 @@ func (mh *MessageHandler) TopicResponseMessage(tr *sdkinterfaces.TopicResponse)
 					"type":                topic.Type,
 					"symbl_message_index": topic.MessageIndex,
 					"root_words":          convertRootWordToSlice(topic.RootWords),
-					"raw":                 string(data),
+					"raw":                 raw,
+					"raw_key_id":          rawKeyId,
 				})
 				if err != nil {
 					klog.V(1).Infof("neo4j.Run failed create conversation object. Err: %v\n", err)
@@ -415,7 +591,7 @@ func (mh *MessageHandler) TopicResponseMessage(tr *sdkinterfaces.TopicResponse)
 
 		// associate topic to message
 		for _, ref := range topic.MessageReferences {
-			_, err = (*mh.neo4jMgr).ExecuteWrite(ctx,
+			_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 				func(tx neo4j.ManagedTransaction) (any, error) {
 					createTopicsQuery := interfaces.ReplaceIndexes(`
 						MATCH (t:Topic { topicId: $topic_id })
@@ -455,9 +631,9 @@ func (mh *MessageHandler) TopicResponseMessage(tr *sdkinterfaces.TopicResponse)
 		return err
 	}
 
-	err = (*mh.rabbitMgr).PublishMessageByName(interfaces.RabbitExchangeTopic, data)
+	err = mh.publish(interfaces.RabbitExchangeTopic, data, true, false)
 	if err != nil {
-		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		klog.V(1).Infof("publish failed. Err: %v\n", err)
 		klog.V(6).Infof("InitializedConversation LEAVE\n")
 		return err
 	}
@@ -489,12 +665,20 @@ func (mh *MessageHandler) TrackerResponseMessage(tr *sdkinterfaces.TrackerRespon
 	klog.V(2).Infof("TrackerResponseMessage:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// field level encryption
+	raw, rawKeyId, err := mh.protect(interfaces.DatabasePropertyRaw, string(data))
+	if err != nil {
+		klog.V(1).Infof("protect raw failed. Err: %v\n", err)
+		klog.V(6).Infof("TrackerResponseMessage LEAVE\n")
+		return err
+	}
+
 	// write the object to the database
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	for _, tracker := range tr.Trackers {
-		_, err := (*mh.neo4jMgr).ExecuteWrite(ctx,
+		_, err := journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 			func(tx neo4j.ManagedTransaction) (any, error) {
 				createTrackersQuery := interfaces.ReplaceIndexes(`
 					MATCH (c:Conversation { #conversation_index#: $conversation_id })
@@ -503,7 +687,7 @@ func (mh *MessageHandler) TrackerResponseMessage(tr *sdkinterfaces.TrackerRespon
 							t.lastAccessed = timestamp()
 						ON MATCH SET
 							t.lastAccessed = timestamp()
-					SET t = { #tracker_index#: $tracker_id, name: $tracker_name, raw: $raw }
+					SET t = { #tracker_index#: $tracker_id, name: $tracker_name, raw: $raw, rawKeyId: $raw_key_id }
 					MERGE (c)-[x:TRACKER { #conversation_index#: $conversation_id }]-(t)
 					SET x = { #conversation_index#: $conversation_id }
 					`)
@@ -511,7 +695,8 @@ func (mh *MessageHandler) TrackerResponseMessage(tr *sdkinterfaces.TrackerRespon
 					"conversation_id": mh.conversationId,
 					"tracker_id":      tracker.ID,
 					"tracker_name":    tracker.Name,
-					"raw":             string(data),
+					"raw":             raw,
+					"raw_key_id":      rawKeyId,
 				})
 				if err != nil {
 					klog.V(1).Infof("neo4j.Run failed create conversation object. Err: %v\n", err)
@@ -530,7 +715,7 @@ func (mh *MessageHandler) TrackerResponseMessage(tr *sdkinterfaces.TrackerRespon
 
 			// messages
 			for _, msgRef := range match.MessageRefs {
-				_, err = (*mh.neo4jMgr).ExecuteWrite(ctx,
+				_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 					func(tx neo4j.ManagedTransaction) (any, error) {
 						createTopicsQuery := interfaces.ReplaceIndexes(`
 							MATCH (t:Tracker { #tracker_index#: $tracker_id })
@@ -559,7 +744,7 @@ func (mh *MessageHandler) TrackerResponseMessage(tr *sdkinterfaces.TrackerRespon
 
 			// insights
 			for _, inRef := range match.InsightRefs {
-				_, err = (*mh.neo4jMgr).ExecuteWrite(ctx,
+				_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 					func(tx neo4j.ManagedTransaction) (any, error) {
 						createTrackerMatchQuery := interfaces.ReplaceIndexes(`
 							MATCH (t:Tracker { #tracker_index#: $tracker_id })
@@ -601,9 +786,9 @@ func (mh *MessageHandler) TrackerResponseMessage(tr *sdkinterfaces.TrackerRespon
 		return err
 	}
 
-	err = (*mh.rabbitMgr).PublishMessageByName(interfaces.RabbitExchangeTracker, data)
+	err = mh.publish(interfaces.RabbitExchangeTracker, data, true, false)
 	if err != nil {
-		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		klog.V(1).Infof("publish failed. Err: %v\n", err)
 		klog.V(6).Infof("InitializedConversation LEAVE\n")
 		return err
 	}
@@ -636,6 +821,14 @@ func (mh *MessageHandler) EntityResponseMessage(er *sdkinterfaces.EntityResponse
 	klog.V(2).Infof("EntityResponseMessage:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// field level encryption
+	raw, rawKeyId, err := mh.protect(interfaces.DatabasePropertyRaw, string(data))
+	if err != nil {
+		klog.V(1).Infof("protect raw failed. Err: %v\n", err)
+		klog.V(6).Infof("EntityResponseMessage LEAVE\n")
+		return err
+	}
+
 	// write the object to the database
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -646,7 +839,7 @@ func (mh *MessageHandler) EntityResponseMessage(er *sdkinterfaces.EntityResponse
 		entityId := fmt.Sprintf("%s_%s_%s", entity.Type, entity.SubType, entity.Category)
 
 		// entity
-		_, err := (*mh.neo4jMgr).ExecuteWrite(ctx,
+		_, err := journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 			func(tx neo4j.ManagedTransaction) (any, error) {
 				createEntitiesQuery := interfaces.ReplaceIndexes(`
 					MATCH (c:Conversation { #conversation_index#: $conversation_id })
@@ -655,7 +848,7 @@ func (mh *MessageHandler) EntityResponseMessage(er *sdkinterfaces.EntityResponse
 							e.lastAccessed = timestamp()
 						ON MATCH SET
 							e.lastAccessed = timestamp()
-					SET e = { #entity_index#: $entity_id, type: $type, subType: $sub_type, category: $category, raw: $raw }
+					SET e = { #entity_index#: $entity_id, type: $type, subType: $sub_type, category: $category, raw: $raw, rawKeyId: $raw_key_id }
 					MERGE (c)-[x:ENTITY { #conversation_index#: $conversation_id }]-(e)
 					SET x = { #conversation_index#: $conversation_id }
 					`)
@@ -665,7 +858,8 @@ func (mh *MessageHandler) EntityResponseMessage(er *sdkinterfaces.EntityResponse
 					"type":            entity.Type,
 					"sub_type":        entity.SubType,
 					"category":        entity.Category,
-					"raw":             string(data),
+					"raw":             raw,
+					"raw_key_id":      rawKeyId,
 				})
 				if err != nil {
 					klog.V(1).Infof("neo4j.Run failed create conversation object. Err: %v\n", err)
@@ -684,7 +878,7 @@ func (mh *MessageHandler) EntityResponseMessage(er *sdkinterfaces.EntityResponse
 
 			// message
 			for _, msgRef := range match.MessageRefs {
-				_, err = (*mh.neo4jMgr).ExecuteWrite(ctx,
+				_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 					func(tx neo4j.ManagedTransaction) (any, error) {
 						createEntitiesQuery := interfaces.ReplaceIndexes(`
 							MATCH (e:Entity { #entity_index#: $entity_id })
@@ -726,9 +920,9 @@ func (mh *MessageHandler) EntityResponseMessage(er *sdkinterfaces.EntityResponse
 		return err
 	}
 
-	err = (*mh.rabbitMgr).PublishMessageByName(interfaces.RabbitExchangeEntity, data)
+	err = mh.publish(interfaces.RabbitExchangeEntity, data, true, false)
 	if err != nil {
-		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		klog.V(1).Infof("publish failed. Err: %v\n", err)
 		klog.V(6).Infof("InitializedConversation LEAVE\n")
 		return err
 	}
@@ -767,10 +961,35 @@ func (mh *MessageHandler) TeardownConversation(tm *sdkinterfaces.TeardownMessage
 	klog.V(2).Infof("TeardownConversation:\n%v\n\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// neo4j mark the conversation as complete
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
+		func(tx neo4j.ManagedTransaction) (any, error) {
+			completeConversationQuery := interfaces.ReplaceIndexes(`
+				MATCH (c:Conversation { #conversation_index#: $conversation_id })
+				SET c.completed = true, c.completedTime = timestamp()
+				`)
+			result, err := tx.Run(ctx, completeConversationQuery, map[string]any{
+				"conversation_id": mh.conversationId,
+			})
+			if err != nil {
+				klog.V(1).Infof("neo4j.Run failed complete conversation object. Err: %v\n", err)
+				return nil, err
+			}
+			return result.Collect(ctx)
+		})
+	if err != nil {
+		// don't block the teardown notification on this
+		klog.V(1).Infof("neo4j.ExecuteWrite failed. Err: %v\n", err)
+	}
+
 	// rabbitmq
-	err = (*mh.rabbitMgr).PublishMessageByName(interfaces.RabbitExchangeConversationTeardown, data)
+	// goes through the queue so it lands after everything already queued for this conversation
+	err = mh.publish(interfaces.RabbitExchangeConversationTeardown, data, false, true)
 	if err != nil {
-		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		klog.V(1).Infof("publish failed. Err: %v\n", err)
 		klog.V(6).Infof("InitializedConversation LEAVE\n")
 		return err
 	}
@@ -834,13 +1053,28 @@ func (mh *MessageHandler) handleInsight(insight *sdkinterfaces.Insight, squenceN
 	klog.V(2).Infof("handleInsight:\n%v\n", string(prettyJson))
 	klog.V(6).Infof("-------------------------------\n\n")
 
+	// field level encryption
+	raw, rawKeyId, err := mh.protect(interfaces.DatabasePropertyRaw, string(data))
+	if err != nil {
+		klog.V(1).Infof("protect raw failed. Err: %v\n", err)
+		klog.V(6).Infof("handleInsight LEAVE\n")
+		return err
+	}
+
 	// write the object to the database
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	content, contentKeyId, err := mh.protect(interfaces.DatabasePropertyContent, insight.Payload.Content)
+	if err != nil {
+		klog.V(1).Infof("protect content failed. Err: %v\n", err)
+		klog.V(6).Infof("handleInsight LEAVE\n")
+		return err
+	}
+
 	// if we need to do something with them
 	// for records, message := range mr.Messages {
-	_, err = (*mh.neo4jMgr).ExecuteWrite(ctx,
+	_, err = journal.ExecuteWrite(ctx, mh.journal, *mh.neo4jMgr,
 		func(tx neo4j.ManagedTransaction) (any, error) {
 			createInsightQuery := interfaces.ReplaceIndexes(`
 				MATCH (c:Conversation { #conversation_index#: $conversation_id })
@@ -849,7 +1083,7 @@ func (mh *MessageHandler) handleInsight(insight *sdkinterfaces.Insight, squenceN
 						i.lastAccessed = timestamp()
 					ON MATCH SET
 						i.lastAccessed = timestamp()
-				SET i = { #insight_index#: $insight_id, type: $type, content: $content, sequenceNumber: $sequence_number, assigneeId: $assignee_id, raw: $raw }
+				SET i = { #insight_index#: $insight_id, type: $type, content: $content, contentKeyId: $content_key_id, sequenceNumber: $sequence_number, assigneeId: $assignee_id, raw: $raw, rawKeyId: $raw_key_id }
 				MERGE (u:User { #user_index#: $user_id })
 					ON CREATE SET
 						u.lastAccessed = timestamp()
@@ -865,13 +1099,15 @@ func (mh *MessageHandler) handleInsight(insight *sdkinterfaces.Insight, squenceN
 				"conversation_id": mh.conversationId,
 				"insight_id":      insight.ID,
 				"type":            insight.Type,
-				"content":         insight.Payload.Content,
+				"content":         content,
+				"content_key_id":  contentKeyId,
 				"sequence_number": squenceNumber,
 				"assignee_id":     insight.Assignee.UserID,
 				"user_real_id":    insight.From.ID,
 				"user_id":         insight.From.UserID,
 				"user_name":       insight.From.Name,
-				"raw":             string(data),
+				"raw":             raw,
+				"raw_key_id":      rawKeyId,
 			})
 			if err != nil {
 				klog.V(1).Infof("neo4j.Run failed create conversation object. Err: %v\n", err)
@@ -898,9 +1134,9 @@ func (mh *MessageHandler) handleInsight(insight *sdkinterfaces.Insight, squenceN
 		return err
 	}
 
-	err = (*mh.rabbitMgr).PublishMessageByName(interfaces.RabbitExchangeInsight, data)
+	err = mh.publish(interfaces.RabbitExchangeInsight, data, true, false)
 	if err != nil {
-		klog.V(1).Infof("PublishMessageByName failed. Err: %v\n", err)
+		klog.V(1).Infof("publish failed. Err: %v\n", err)
 		klog.V(6).Infof("InitializedConversation LEAVE\n")
 		return err
 	}
@@ -919,3 +1155,21 @@ func convertRootWordToSlice(words []sdkinterfaces.RootWord) []string {
 	}
 	return arr
 }
+
+// protect returns the value to store for a property and its key id. Only properties configured
+// in EncryptedProperties are encrypted, the key id is nil otherwise
+func (mh *MessageHandler) protect(name, value string) (any, any, error) {
+	if !mh.encrypted[name] {
+		return value, nil, nil
+	}
+	return interfaces.EncryptProperty(mh.cipher, value)
+}
+
+// convertTimeToMillis returns the epoch millis of a Symbl timestamp or nil when it can't be parsed
+func convertTimeToMillis(str string) any {
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return nil
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package routing
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	encryption "github.com/dvonthenen/enterprise-reference-implementation/pkg/encryption"
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+)
+
+func TestNewHandlerEncryptedProperties(t *testing.T) {
+	cipher, err := encryption.NewAESCipher(encryption.AESCipherOptions{
+		Keys:         map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)},
+		CurrentKeyId: "k1",
+	})
+	if err != nil {
+		t.Fatalf("NewAESCipher() err = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		cipher     interfaces.Cipher
+		properties []string
+		wantErr    error
+	}{
+		{"none", nil, nil, nil},
+		{"content and raw", cipher, []string{interfaces.DatabasePropertyContent, interfaces.DatabasePropertyRaw}, nil},
+		{"no cipher", nil, []string{interfaces.DatabasePropertyContent}, ErrInvalidInput},
+		{"unsupported", cipher, []string{"value"}, ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHandler(MessageHandlerOptions{
+				ConversationId:      "conv1",
+				Cipher:              tt.cipher,
+				EncryptedProperties: tt.properties,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewHandler() err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertTimeToMillis(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want any
+	}{
+		{"utc", "2022-12-01T10:00:00Z", int64(1669888800000)},
+		{"nanos", "2022-12-01T10:00:00.123456789Z", int64(1669888800123)},
+		{"offset", "2022-12-01T12:00:00+02:00", int64(1669888800000)},
+		{"empty", "", nil},
+		{"unparseable", "yesterday", nil},
+		{"date only", "2022-12-01", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertTimeToMillis(tt.str); got != tt.want {
+				t.Errorf("convertTimeToMillis(%q) = %v, want %v", tt.str, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,9 +4,15 @@
 package routing
 
 import (
+	"sync"
+	"time"
+
 	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
 	symblinterfaces "github.com/dvonthenen/symbl-go-sdk/pkg/api/streaming/v1/interfaces"
 	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	journal "github.com/dvonthenen/enterprise-reference-implementation/pkg/journal"
 )
 
 /*
@@ -27,6 +33,19 @@ type MessageHandlerOptions struct {
 
 	// neo4j session
 	RabbitMgr *rabbitinterfaces.Manager
+
+	// field level encryption
+	Cipher              interfaces.Cipher
+	EncryptedProperties []string
+
+	// cypher journal
+	Journal *journal.Journal
+
+	// backpressure, zero uses DefaultBackpressurePollInterval, DefaultBackpressureMaxPause and
+	// DefaultBackpressureTTL
+	BackpressurePollInterval time.Duration
+	BackpressureMaxPause     time.Duration
+	BackpressureTTL          time.Duration
 }
 
 /*
@@ -48,4 +67,34 @@ type MessageHandler struct {
 
 	// rabbitmq
 	rabbitMgr *rabbitinterfaces.Manager
+
+	// field level encryption
+	cipher    interfaces.Cipher
+	encrypted map[string]bool
+
+	// cypher journal
+	journal *journal.Journal
+
+	// backpressure from the analyzer
+	backpressurePause        bool
+	backpressureDelay        time.Duration
+	backpressureExpires      time.Time
+	backpressurePauseStart   time.Time
+	backpressurePollInterval time.Duration
+	backpressureMaxPause     time.Duration
+	backpressureTTL          time.Duration
+	mu                       sync.Mutex
+
+	// publishing runs on its own goroutine so backpressure never blocks the websocket reader
+	publishChan chan *publishRequest
+	publishStop chan struct{}
+	publishMu   sync.Mutex
+}
+
+// publishRequest is a message waiting to be published to the Analyzer
+type publishRequest struct {
+	name     string
+	data     []byte
+	throttle bool
+	done     chan error
 }
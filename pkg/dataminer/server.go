@@ -126,6 +126,10 @@ func (s *Server) redirectProxy(w http.ResponseWriter, r *http.Request) {
 		KeyFile:           s.options.KeyFile,
 		Neo4jMgr:          &session,
 		ProxyMgr:          &manager,
+
+		Cipher:              s.options.Cipher,
+		EncryptedProperties: s.options.EncryptedProperties,
+		Journal:             s.options.Journal,
 	})
 
 	err := server.Init()
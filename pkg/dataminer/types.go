@@ -11,6 +11,8 @@ import (
 	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
 	instance "github.com/dvonthenen/enterprise-reference-implementation/pkg/dataminer/instance"
+	interfaces "github.com/dvonthenen/enterprise-reference-implementation/pkg/interfaces"
+	journal "github.com/dvonthenen/enterprise-reference-implementation/pkg/journal"
 )
 
 // Credentials is the input needed to login to neo4j
@@ -27,6 +29,15 @@ type ServerOptions struct {
 	StartPort int
 	EndPort   int
 	RabbitURI string
+
+	// field level encryption, property names (ie content, raw) are encrypted using Cipher.
+	// only content and raw are supported. the transcript text copied onto ENTITY_MESSAGE_REF.value
+	// and TRACKER_*_REF.value is not covered and is stored in plaintext
+	Cipher              interfaces.Cipher
+	EncryptedProperties []string
+
+	// optional log of all executed Cypher writes for replay
+	Journal *journal.Journal
 }
 
 type Server struct {
@@ -0,0 +1,80 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	klog "k8s.io/klog/v2"
+)
+
+func NewAESCipher(options AESCipherOptions) (*AESCipher, error) {
+	if len(options.Keys) == 0 || len(options.CurrentKeyId) == 0 {
+		klog.V(1).Infof("Keys or CurrentKeyId is empty\n")
+		return nil, ErrInvalidInput
+	}
+	if options.Keys[options.CurrentKeyId] == nil {
+		klog.V(1).Infof("CurrentKeyId (%s) not found in Keys\n", options.CurrentKeyId)
+		return nil, ErrKeyNotFound
+	}
+
+	keys := make(map[string]cipher.AEAD)
+	for keyId, key := range options.Keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			klog.V(1).Infof("aes.NewCipher(%s) failed. Err: %v\n", keyId, err)
+			return nil, err
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			klog.V(1).Infof("cipher.NewGCM(%s) failed. Err: %v\n", keyId, err)
+			return nil, err
+		}
+		keys[keyId] = gcm
+	}
+
+	c := &AESCipher{
+		keys:         keys,
+		currentKeyId: options.CurrentKeyId,
+	}
+	return c, nil
+}
+
+func (c *AESCipher) Encrypt(plaintext []byte) ([]byte, string, error) {
+	gcm := c.keys[c.currentKeyId]
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		klog.V(1).Infof("rand.Reader failed. Err: %v\n", err)
+		return nil, "", err
+	}
+
+	// nonce is prepended to the sealed data
+	return gcm.Seal(nonce, nonce, plaintext, nil), c.currentKeyId, nil
+}
+
+func (c *AESCipher) Decrypt(ciphertext []byte, keyId string) ([]byte, error) {
+	gcm := c.keys[keyId]
+	if gcm == nil {
+		klog.V(1).Infof("keyId (%s) not found\n", keyId)
+		return nil, ErrKeyNotFound
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, ErrCiphertextTooShort
+	}
+
+	plaintext, err := gcm.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+	if err != nil {
+		klog.V(1).Infof("gcm.Open failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	return plaintext, nil
+}
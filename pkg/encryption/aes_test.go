@@ -0,0 +1,127 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+var (
+	testKey1 = bytes.Repeat([]byte{1}, 32)
+	testKey2 = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestAESCipherRoundTrip(t *testing.T) {
+	c, err := NewAESCipher(AESCipherOptions{
+		Keys:         map[string][]byte{"k1": testKey1},
+		CurrentKeyId: "k1",
+	})
+	if err != nil {
+		t.Fatalf("NewAESCipher() err = %v", err)
+	}
+
+	plaintext := []byte("hello world")
+	ciphertext, keyId, err := c.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() err = %v", err)
+	}
+	if keyId != "k1" {
+		t.Errorf("Encrypt() keyId = %s, want k1", keyId)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Errorf("Encrypt() ciphertext contains the plaintext")
+	}
+
+	decrypted, err := c.Decrypt(ciphertext, keyId)
+	if err != nil {
+		t.Fatalf("Decrypt() err = %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("Decrypt() = %q, want %q", decrypted, plaintext)
+	}
+}
+
+func TestAESCipherRotation(t *testing.T) {
+	before, err := NewAESCipher(AESCipherOptions{
+		Keys:         map[string][]byte{"k1": testKey1},
+		CurrentKeyId: "k1",
+	})
+	if err != nil {
+		t.Fatalf("NewAESCipher() err = %v", err)
+	}
+	oldCiphertext, oldKeyId, err := before.Encrypt([]byte("old"))
+	if err != nil {
+		t.Fatalf("Encrypt() err = %v", err)
+	}
+
+	// k1 is retired but kept in the key ring
+	after, err := NewAESCipher(AESCipherOptions{
+		Keys:         map[string][]byte{"k1": testKey1, "k2": testKey2},
+		CurrentKeyId: "k2",
+	})
+	if err != nil {
+		t.Fatalf("NewAESCipher() err = %v", err)
+	}
+
+	decrypted, err := after.Decrypt(oldCiphertext, oldKeyId)
+	if err != nil {
+		t.Fatalf("Decrypt() old data err = %v", err)
+	}
+	if string(decrypted) != "old" {
+		t.Errorf("Decrypt() old data = %q, want %q", decrypted, "old")
+	}
+
+	_, keyId, err := after.Encrypt([]byte("new"))
+	if err != nil {
+		t.Fatalf("Encrypt() err = %v", err)
+	}
+	if keyId != "k2" {
+		t.Errorf("Encrypt() keyId = %s, want k2", keyId)
+	}
+}
+
+func TestAESCipherErrors(t *testing.T) {
+	c, err := NewAESCipher(AESCipherOptions{
+		Keys:         map[string][]byte{"k1": testKey1},
+		CurrentKeyId: "k1",
+	})
+	if err != nil {
+		t.Fatalf("NewAESCipher() err = %v", err)
+	}
+	ciphertext, _, err := c.Encrypt([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Encrypt() err = %v", err)
+	}
+
+	_, err = c.Decrypt(ciphertext, "unknown")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Decrypt() unknown key err = %v, want %v", err, ErrKeyNotFound)
+	}
+
+	_, err = c.Decrypt([]byte("short"), "k1")
+	if !errors.Is(err, ErrCiphertextTooShort) {
+		t.Errorf("Decrypt() short err = %v, want %v", err, ErrCiphertextTooShort)
+	}
+
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	_, err = c.Decrypt(ciphertext, "k1")
+	if err == nil {
+		t.Errorf("Decrypt() tampered ciphertext err = nil")
+	}
+
+	_, err = NewAESCipher(AESCipherOptions{})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("NewAESCipher() empty err = %v, want %v", err, ErrInvalidInput)
+	}
+
+	_, err = NewAESCipher(AESCipherOptions{
+		Keys:         map[string][]byte{"k1": testKey1},
+		CurrentKeyId: "k2",
+	})
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("NewAESCipher() missing current key err = %v, want %v", err, ErrKeyNotFound)
+	}
+}
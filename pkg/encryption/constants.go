@@ -0,0 +1,19 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package encryption
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrKeyNotFound the key id is not in the key ring
+	ErrKeyNotFound = errors.New("the key id is not in the key ring")
+
+	// ErrCiphertextTooShort the ciphertext is shorter than the nonce
+	ErrCiphertextTooShort = errors.New("the ciphertext is shorter than the nonce")
+)
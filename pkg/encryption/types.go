@@ -0,0 +1,24 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package encryption
+
+import (
+	"crypto/cipher"
+)
+
+// AESCipherOptions to init the AES-GCM cipher
+type AESCipherOptions struct {
+	// key ring of keyId to 16, 24 or 32 byte keys. keep retired keys here so existing
+	// properties remain readable after a rotation
+	Keys map[string][]byte
+
+	// key used for all new writes
+	CurrentKeyId string
+}
+
+// AESCipher implements interfaces.Cipher using AES-GCM
+type AESCipher struct {
+	keys         map[string]cipher.AEAD
+	currentKeyId string
+}
@@ -3,10 +3,17 @@
 
 package interfaces
 
+import (
+	"errors"
+)
+
 const (
 	// user/app level notification messages
 	UserMessageTypeAssociation string = "message_association"
 
+	// control messages from the analyzer to the dataminer proxies
+	ControlMessageTypeBackpressure string = "backpressure"
+
 	// rabbit message names/exchanges
 	RabbitExchangeConversationInit     string = "conversation-created"
 	RabbitExchangeMessage              string = "message-created"
@@ -16,6 +23,7 @@ const (
 	RabbitExchangeInsight              string = "insight-created"
 	RabbitExchangeConversationTeardown string = "conversation-teardown"
 	RabbitClientNotifications          string = "client-notification"
+	RabbitExchangeBackpressure         string = "backpressure"
 
 	// neo4j node ID names/index/uniqueIds
 	DatabaseIndexConversation string = "conversationId"
@@ -26,4 +34,16 @@ const (
 	DatabaseIndexInsight      string = "insightId"
 	DatabaseIndexEntity       string = "entityId" // = entity.Type + "_" + entity.SubType + "_" + entity.Category
 	DatabaseIndexEntityMatch  string = "matchId"  // = conversationId + "_" + entityId
+
+	// neo4j properties that can be encrypted with a Cipher
+	DatabasePropertyContent string = "content"
+	DatabasePropertyRaw     string = "raw"
+
+	// neo4j property suffix holding the key id of an encrypted property
+	DatabasePropertyKeyIdSuffix string = "KeyId"
+)
+
+var (
+	// ErrCipherNil an encrypted property was found but no cipher is configured
+	ErrCipherNil = errors.New("an encrypted property was found but no cipher is configured")
 )
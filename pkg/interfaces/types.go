@@ -38,6 +38,35 @@ type ClientMessageType struct {
 	Type string `json:"type,omitempty"`
 }
 
+/*
+	Backpressure signal sent by the Analyzer to all Dataminer proxies
+
+	When Pause is set, proxies hold insight messages until resumed. DelayMs is
+	a delay applied before each publish. A message with both unset clears the signal.
+
+	The exchange is auto-deleted and not durable, so a proxy that starts after the
+	signal was sent never sees it. The Analyzer re-broadcasts an active signal on an
+	interval to cover that case, and proxies drop a signal that isn't refreshed within
+	TtlMs so a lost clear doesn't hold them forever.
+*/
+type BackpressureMessage struct {
+	Type    string `json:"type,omitempty"`
+	Pause   bool   `json:"pause,omitempty"`
+	DelayMs int64  `json:"delayMs,omitempty"`
+	TtlMs   int64  `json:"ttlMs,omitempty"`
+}
+
+/*
+	Field level encryption for sensitive graph properties
+*/
+type Cipher interface {
+	// Encrypt returns the ciphertext and the id of the key used
+	Encrypt(plaintext []byte) ([]byte, string, error)
+
+	// Decrypt reverses Encrypt using the key identified by keyId
+	Decrypt(ciphertext []byte, keyId string) ([]byte, error)
+}
+
 /*
 	Conversation Insight with Metadata
 */
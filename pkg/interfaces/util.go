@@ -4,6 +4,7 @@
 package interfaces
 
 import (
+	"encoding/base64"
 	"strings"
 )
 
@@ -26,3 +27,44 @@ func ReplaceIndexes(str string) string {
 	}
 	return str
 }
+
+// EncryptProperty returns the value to store and its key id. The key id is nil when cipher is nil
+// so the property is written as plain text and no key id property is set.
+func EncryptProperty(cipher Cipher, value string) (any, any, error) {
+	if cipher == nil {
+		return value, nil, nil
+	}
+
+	ciphertext, keyId, err := cipher.Encrypt([]byte(value))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return base64.StdEncoding.EncodeToString(ciphertext), keyId, nil
+}
+
+// DecryptProperty reads the named property from a node or relationship, decrypting it when
+// a matching key id property was stored alongside it
+func DecryptProperty(cipher Cipher, props map[string]any, name string) (string, error) {
+	value, _ := props[name].(string)
+
+	keyId, _ := props[name+DatabasePropertyKeyIdSuffix].(string)
+	if len(keyId) == 0 {
+		return value, nil
+	}
+	if cipher == nil {
+		return "", ErrCipherNil
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := cipher.Decrypt(ciphertext, keyId)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
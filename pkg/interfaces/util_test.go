@@ -0,0 +1,74 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package interfaces
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	encryption "github.com/dvonthenen/enterprise-reference-implementation/pkg/encryption"
+)
+
+func newTestCipher(t *testing.T) Cipher {
+	c, err := encryption.NewAESCipher(encryption.AESCipherOptions{
+		Keys:         map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)},
+		CurrentKeyId: "k1",
+	})
+	if err != nil {
+		t.Fatalf("NewAESCipher() err = %v", err)
+	}
+	return c
+}
+
+func TestEncryptDecryptProperty(t *testing.T) {
+	cipher := newTestCipher(t)
+
+	value, keyId, err := EncryptProperty(cipher, "hello")
+	if err != nil {
+		t.Fatalf("EncryptProperty() err = %v", err)
+	}
+	if value == "hello" || keyId != "k1" {
+		t.Fatalf("EncryptProperty() = %v, %v, want ciphertext and k1", value, keyId)
+	}
+
+	props := map[string]any{
+		"content":                               value,
+		"content" + DatabasePropertyKeyIdSuffix: keyId,
+	}
+	content, err := DecryptProperty(cipher, props, "content")
+	if err != nil {
+		t.Fatalf("DecryptProperty() err = %v", err)
+	}
+	if content != "hello" {
+		t.Errorf("DecryptProperty() = %q, want %q", content, "hello")
+	}
+
+	_, err = DecryptProperty(nil, props, "content")
+	if !errors.Is(err, ErrCipherNil) {
+		t.Errorf("DecryptProperty() nil cipher err = %v, want %v", err, ErrCipherNil)
+	}
+}
+
+func TestPropertyPlaintext(t *testing.T) {
+	value, keyId, err := EncryptProperty(nil, "hello")
+	if err != nil {
+		t.Fatalf("EncryptProperty() err = %v", err)
+	}
+	if value != "hello" || keyId != nil {
+		t.Errorf("EncryptProperty() nil cipher = %v, %v, want hello, nil", value, keyId)
+	}
+
+	// properties written before encryption was enabled have no key id
+	props := map[string]any{"content": "hello"}
+	for _, cipher := range []Cipher{nil, newTestCipher(t)} {
+		content, err := DecryptProperty(cipher, props, "content")
+		if err != nil {
+			t.Fatalf("DecryptProperty() err = %v", err)
+		}
+		if content != "hello" {
+			t.Errorf("DecryptProperty() = %q, want %q", content, "hello")
+		}
+	}
+}
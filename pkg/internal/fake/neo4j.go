@@ -0,0 +1,145 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package fake provides in-memory stand-ins for the neo4j driver so code that talks to the graph
+// can be exercised without a database
+package fake
+
+import (
+	"context"
+	"sync"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// RunFunc answers a single statement with the records it returns
+type RunFunc func(cypher string, params map[string]any) ([]*neo4j.Record, error)
+
+// Statement is a statement that was run against a Session
+type Statement struct {
+	Cypher     string
+	Parameters map[string]any
+}
+
+// Session is a neo4j.SessionWithContext that hands every statement to OnRun. Only the methods
+// used by this repo are implemented, the embedded interface panics on anything else.
+type Session struct {
+	neo4j.SessionWithContext
+
+	// OnRun answers statements, nil returns no records
+	OnRun RunFunc
+
+	// Retries is the number of ExecuteWrite attempts that are thrown away, as the driver does on
+	// a transient error, before the attempt that commits
+	Retries int
+
+	statements []Statement
+	mu         sync.Mutex
+}
+
+// Statements returns every statement run so far, including ones from discarded attempts
+func (s *Session) Statements() []Statement {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Statement(nil), s.statements...)
+}
+
+func (s *Session) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	return work(&Transaction{session: s})
+}
+
+func (s *Session) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	for s.Retries > 0 {
+		s.Retries--
+		if _, err := work(&Transaction{session: s}); err != nil {
+			return nil, err
+		}
+	}
+	return work(&Transaction{session: s})
+}
+
+func (s *Session) Close(ctx context.Context) error {
+	return nil
+}
+
+func (s *Session) run(cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	s.mu.Lock()
+	s.statements = append(s.statements, Statement{Cypher: cypher, Parameters: params})
+	onRun := s.OnRun
+	s.mu.Unlock()
+
+	var records []*neo4j.Record
+	if onRun != nil {
+		var err error
+		records, err = onRun(cypher, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Result{records: records}, nil
+}
+
+// Transaction is the neo4j.ManagedTransaction passed to work functions by Session
+type Transaction struct {
+	neo4j.ManagedTransaction
+
+	session *Session
+}
+
+func (t *Transaction) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	return t.session.run(cypher, params)
+}
+
+// Result iterates over the records returned by RunFunc
+type Result struct {
+	neo4j.ResultWithContext
+
+	records []*neo4j.Record
+	current *neo4j.Record
+}
+
+func (r *Result) Next(ctx context.Context) bool {
+	if len(r.records) == 0 {
+		r.current = nil
+		return false
+	}
+	r.current, r.records = r.records[0], r.records[1:]
+	return true
+}
+
+func (r *Result) Record() *neo4j.Record {
+	return r.current
+}
+
+func (r *Result) Err() error {
+	return nil
+}
+
+func (r *Result) Collect(ctx context.Context) ([]*neo4j.Record, error) {
+	records := r.records
+	r.records = nil
+	r.current = nil
+	return records, nil
+}
+
+// Driver is a neo4j.DriverWithContext whose sessions are all Session
+type Driver struct {
+	neo4j.DriverWithContext
+
+	Session *Session
+}
+
+func (d *Driver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	return d.Session
+}
+
+// NewRecord builds a record from alternating key, value pairs
+func NewRecord(keyValues ...any) *neo4j.Record {
+	record := &neo4j.Record{}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		record.Keys = append(record.Keys, keyValues[i].(string))
+		record.Values = append(record.Values, keyValues[i+1])
+	}
+	return record
+}
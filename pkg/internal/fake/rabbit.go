@@ -0,0 +1,124 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package fake
+
+import (
+	"sync"
+
+	rabbitinterfaces "github.com/dvonthenen/rabbitmq-manager/pkg/interfaces"
+	rabbitmanager "github.com/dvonthenen/rabbitmq-manager/pkg/manager"
+)
+
+// Message is a message published through a Manager
+type Message struct {
+	Name string
+	Data []byte
+}
+
+// Manager is a rabbitinterfaces.Manager that keeps published messages in memory. Publishers
+// and subscribers are only tracked by name.
+type Manager struct {
+	// Published receives every message as it is published when set
+	Published chan Message
+
+	publishers  map[string]bool
+	subscribers map[string]*rabbitinterfaces.RabbitMessageHandler
+	messages    []Message
+	mu          sync.Mutex
+}
+
+// Messages returns every message published so far
+func (m *Manager) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.messages...)
+}
+
+// Deliver hands data to the handler of the named subscriber
+func (m *Manager) Deliver(name string, data []byte) error {
+	m.mu.Lock()
+	handler := m.subscribers[name]
+	m.mu.Unlock()
+
+	if handler == nil {
+		return rabbitmanager.ErrSubscriberNotFound
+	}
+	return (*handler).ProcessMessage(data)
+}
+
+func (m *Manager) Init() error {
+	return nil
+}
+
+func (m *Manager) Retry() error {
+	return nil
+}
+
+func (m *Manager) CreatePublisher(options rabbitinterfaces.PublisherOptions) (*rabbitinterfaces.Publisher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.publishers == nil {
+		m.publishers = make(map[string]bool)
+	}
+	m.publishers[options.Name] = true
+	return nil, nil
+}
+
+func (m *Manager) CreateSubscriber(options rabbitinterfaces.SubscriberOptions) (*rabbitinterfaces.Subscriber, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.subscribers == nil {
+		m.subscribers = make(map[string]*rabbitinterfaces.RabbitMessageHandler)
+	}
+	m.subscribers[options.Name] = options.Handler
+	return nil, nil
+}
+
+func (m *Manager) GetPublisherByName(name string) (*rabbitinterfaces.Publisher, error) {
+	return nil, rabbitmanager.ErrPublisherNotFound
+}
+
+func (m *Manager) GetSubscriberByName(name string) (*rabbitinterfaces.Subscriber, error) {
+	return nil, rabbitmanager.ErrSubscriberNotFound
+}
+
+func (m *Manager) PublishMessageByName(name string, data []byte) error {
+	m.mu.Lock()
+	if !m.publishers[name] {
+		m.mu.Unlock()
+		return rabbitmanager.ErrPublisherNotFound
+	}
+	message := Message{Name: name, Data: append([]byte(nil), data...)}
+	m.messages = append(m.messages, message)
+	published := m.Published
+	m.mu.Unlock()
+
+	if published != nil {
+		published <- message
+	}
+	return nil
+}
+
+func (m *Manager) DeletePublisher(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.publishers, name)
+	return nil
+}
+
+func (m *Manager) DeleteSubscriber(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.subscribers, name)
+	return nil
+}
+
+func (m *Manager) Teardown() error {
+	return nil
+}
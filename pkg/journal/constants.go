@@ -0,0 +1,25 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package journal
+
+import (
+	"errors"
+	"os"
+)
+
+const (
+	// permissions for journal files
+	DefaultFileMode os.FileMode = 0600
+)
+
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrJournalClosed the journal has already been closed
+	ErrJournalClosed = errors.New("the journal has already been closed")
+
+	// ErrJournalWrite the transaction committed but its statements could not be journaled
+	ErrJournalWrite = errors.New("the transaction committed but its statements could not be journaled")
+)
@@ -0,0 +1,210 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	klog "k8s.io/klog/v2"
+)
+
+func New(options JournalOptions) (*Journal, error) {
+	if len(options.FilePath) == 0 && options.Writer == nil {
+		klog.V(1).Infof("FilePath or Writer is required\n")
+		return nil, ErrInvalidInput
+	}
+
+	j := &Journal{
+		options: options,
+		writer:  options.Writer,
+	}
+
+	if options.Writer == nil {
+		err := j.open()
+		if err != nil {
+			klog.V(1).Infof("open failed. Err: %v\n", err)
+			return nil, err
+		}
+	}
+
+	return j, nil
+}
+
+func (j *Journal) open() error {
+	file, err := os.OpenFile(j.options.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, DefaultFileMode)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	j.file = file
+	j.writer = file
+	j.size = info.Size()
+
+	return nil
+}
+
+func (j *Journal) rotate() error {
+	err := j.file.Close()
+	if err != nil {
+		klog.V(1).Infof("file.Close failed. Err: %v\n", err)
+	}
+
+	// nothing is open until open succeeds, Record retries it
+	j.file = nil
+	j.writer = nil
+
+	rotated := fmt.Sprintf("%s.%d", j.options.FilePath, time.Now().UnixNano())
+	err = os.Rename(j.options.FilePath, rotated)
+	if err != nil {
+		// keep appending to the current file rather than lose entries
+		klog.V(1).Infof("os.Rename failed. Err: %v\n", err)
+	} else {
+		klog.V(3).Infof("Journal rotated to %s\n", rotated)
+	}
+
+	return j.open()
+}
+
+// Record stamps the entries with the timestamp, origin and sequence then appends them to the journal
+func (j *Journal) Record(entries ...Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return ErrJournalClosed
+	}
+
+	for _, entry := range entries {
+		timestamp := time.Now().UnixNano()
+		if timestamp <= j.lastTimestamp {
+			timestamp = j.lastTimestamp + 1
+		}
+		j.lastTimestamp = timestamp
+		j.sequence++
+
+		entry.Timestamp = timestamp
+		entry.Origin = j.options.Origin
+		entry.Sequence = j.sequence
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			klog.V(1).Infof("json.Marshal failed. Err: %v\n", err)
+			return err
+		}
+		data = append(data, '\n')
+
+		if j.writer == nil {
+			err = j.open()
+			if err != nil {
+				klog.V(1).Infof("open failed. Err: %v\n", err)
+				return err
+			}
+		}
+
+		if j.file != nil && j.options.MaxBytes > 0 && j.size > 0 && j.size+int64(len(data)) > j.options.MaxBytes {
+			err = j.rotate()
+			if err != nil {
+				klog.V(1).Infof("rotate failed. Err: %v\n", err)
+				return err
+			}
+		}
+
+		n, err := j.writer.Write(data)
+		j.size += int64(n)
+		if err != nil {
+			klog.V(1).Infof("Write failed. Err: %v\n", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Err returns the first failure to journal a committed transaction. Once set, the graph holds
+// writes the journal doesn't and replaying it will not reproduce the graph
+func (j *Journal) Err() error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.err
+}
+
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.closed = true
+
+	if j.file != nil {
+		err := j.file.Close()
+		j.file = nil
+		return err
+	}
+
+	return nil
+}
+
+// ExecuteWrite runs work in a write transaction on session and journals the statements it ran,
+// but only once the transaction commits. A nil journal just runs the transaction. If the
+// statements can't be journaled, the failure is logged and kept on Journal.Err and the committed
+// result is returned, the journal must not stop ingestion. In Strict mode the error wrapping
+// ErrJournalWrite is returned as well.
+func ExecuteWrite(ctx context.Context, j *Journal, session neo4j.SessionWithContext, work neo4j.ManagedTransactionWork) (any, error) {
+	if j == nil {
+		return session.ExecuteWrite(ctx, work)
+	}
+
+	var recorder *RecordingTransaction
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// the driver may retry work, only keep the statements from the last attempt
+		recorder = &RecordingTransaction{ManagedTransaction: tx}
+		return work(recorder)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = j.Record(recorder.entries...)
+	if err != nil {
+		klog.V(1).Infof("Journal.Record failed. Err: %v\n", err)
+
+		j.mu.Lock()
+		if j.err == nil {
+			j.err = err
+		}
+		j.mu.Unlock()
+
+		if j.options.Strict {
+			return result, fmt.Errorf("%w: %v", ErrJournalWrite, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (rt *RecordingTransaction) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	result, err := rt.ManagedTransaction.Run(ctx, cypher, params)
+	if err == nil {
+		rt.entries = append(rt.entries, Entry{
+			Statement:  cypher,
+			Parameters: params,
+		})
+	}
+	return result, err
+}
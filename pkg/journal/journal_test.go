@@ -0,0 +1,365 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	fake "github.com/dvonthenen/enterprise-reference-implementation/pkg/internal/fake"
+)
+
+func runStatement(ctx context.Context, j *Journal, session neo4j.SessionWithContext, cypher string, params map[string]any) error {
+	_, err := ExecuteWrite(ctx, j, session, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, cypher, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Collect(ctx)
+	})
+	return err
+}
+
+func readEntries(t *testing.T, reader io.Reader) []Entry {
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("json.Unmarshal() err = %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	j, err := New(JournalOptions{Writer: &buf, Origin: "dataminer"})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+
+	statements := []fake.Statement{
+		{
+			Cypher: "MERGE (c:Conversation { conversationId: $conversation_id })",
+			Parameters: map[string]any{
+				"conversation_id": "conv1",
+			},
+		},
+		{
+			Cypher: "MATCH (c:Conversation { conversationId: $conversation_id }) SET c.score = $score, c.count = $count",
+			Parameters: map[string]any{
+				"conversation_id": "conv1",
+				"score":           1.5,
+				"count":           int64(42),
+				"tags":            []any{"a", int64(1)},
+				"nested":          map[string]any{"weight": int64(2), "ratio": 0.25},
+				"completed":       true,
+			},
+		},
+	}
+
+	original := &fake.Session{}
+	for _, statement := range statements {
+		if err := runStatement(ctx, j, original, statement.Cypher, statement.Parameters); err != nil {
+			t.Fatalf("ExecuteWrite() err = %v", err)
+		}
+	}
+
+	entries := readEntries(t, bytes.NewReader(buf.Bytes()))
+	if len(entries) != len(statements) {
+		t.Fatalf("journaled %d entries, want %d", len(entries), len(statements))
+	}
+	for i, entry := range entries {
+		if entry.Origin != "dataminer" || entry.Sequence != uint64(i+1) {
+			t.Errorf("entry %d origin, sequence = %s, %d, want dataminer, %d", i, entry.Origin, entry.Sequence, i+1)
+		}
+		if i > 0 && entry.Timestamp <= entries[i-1].Timestamp {
+			t.Errorf("entry %d timestamp %d is not after %d", i, entry.Timestamp, entries[i-1].Timestamp)
+		}
+	}
+
+	replayed := &fake.Session{}
+	count, err := ReplayCypherJournal(ctx, replayed, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReplayCypherJournal() err = %v", err)
+	}
+	if count != len(statements) {
+		t.Errorf("ReplayCypherJournal() = %d, want %d", count, len(statements))
+	}
+	if !reflect.DeepEqual(replayed.Statements(), original.Statements()) {
+		t.Errorf("replayed %v, want %v", replayed.Statements(), original.Statements())
+	}
+}
+
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{"int", json.Number("42"), int64(42)},
+		{"negative int", json.Number("-7"), int64(-7)},
+		{"float", json.Number("1.5"), 1.5},
+		{"exponent", json.Number("1e3"), float64(1000)},
+		{"string", "42", "42"},
+		{"bool", true, true},
+		{"nil", nil, nil},
+		{"slice", []any{json.Number("1"), json.Number("2.5"), "a"}, []any{int64(1), 2.5, "a"}},
+		{"map", map[string]any{"a": json.Number("3"), "b": []any{json.Number("0.5")}}, map[string]any{"a": int64(3), "b": []any{0.5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertValue(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue(%v) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cypher.journal")
+
+	j, err := New(JournalOptions{FilePath: path, MaxBytes: 256})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+
+	original := &fake.Session{}
+	for i := 0; i < 10; i++ {
+		err := runStatement(ctx, j, original, "MERGE (c:Conversation { conversationId: $conversation_id })", map[string]any{
+			"conversation_id": strings.Repeat("x", 40),
+			"index":           int64(i),
+		})
+		if err != nil {
+			t.Fatalf("ExecuteWrite() err = %v", err)
+		}
+	}
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close() err = %v", err)
+	}
+
+	rotated, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("filepath.Glob() err = %v", err)
+	}
+	if len(rotated) == 0 {
+		t.Fatalf("journal was not rotated")
+	}
+
+	readers := make([]io.Reader, 0)
+	total := 0
+	for _, file := range append(rotated, path) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("os.ReadFile() err = %v", err)
+		}
+		if len(data) > 256 {
+			t.Errorf("%s is %d bytes, want at most 256", file, len(data))
+		}
+		total += len(readEntries(t, bytes.NewReader(data)))
+		readers = append(readers, bytes.NewReader(data))
+	}
+	if total != 10 {
+		t.Errorf("rotated journals hold %d entries, want 10", total)
+	}
+
+	// the rotated files replay back in the original order
+	replayed := &fake.Session{}
+	if _, err := ReplayCypherJournal(ctx, replayed, readers...); err != nil {
+		t.Fatalf("ReplayCypherJournal() err = %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Statements(), original.Statements()) {
+		t.Errorf("replayed %v, want %v", replayed.Statements(), original.Statements())
+	}
+}
+
+func TestRecordAfterClose(t *testing.T) {
+	var buf bytes.Buffer
+	j, err := New(JournalOptions{Writer: &buf})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close() err = %v", err)
+	}
+
+	err = j.Record(Entry{Statement: "RETURN 1"})
+	if !errors.Is(err, ErrJournalClosed) {
+		t.Errorf("Record() err = %v, want %v", err, ErrJournalClosed)
+	}
+
+	// the transaction commits and ingestion carries on, the failure is kept on Err
+	session := &fake.Session{}
+	if err := runStatement(context.Background(), j, session, "RETURN 1", nil); err != nil {
+		t.Errorf("ExecuteWrite() err = %v, want nil", err)
+	}
+	if len(session.Statements()) != 1 {
+		t.Errorf("ran %d statements, want 1", len(session.Statements()))
+	}
+	if !errors.Is(j.Err(), ErrJournalClosed) {
+		t.Errorf("Err() = %v, want %v", j.Err(), ErrJournalClosed)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("journal has %d bytes, want 0", buf.Len())
+	}
+}
+
+func TestExecuteWriteStrict(t *testing.T) {
+	var buf bytes.Buffer
+	j, err := New(JournalOptions{Writer: &buf, Strict: true})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close() err = %v", err)
+	}
+
+	session := &fake.Session{}
+	err = runStatement(context.Background(), j, session, "RETURN 1", nil)
+	if !errors.Is(err, ErrJournalWrite) {
+		t.Errorf("ExecuteWrite() err = %v, want %v", err, ErrJournalWrite)
+	}
+	if len(session.Statements()) != 1 {
+		t.Errorf("ran %d statements, want 1", len(session.Statements()))
+	}
+	if !errors.Is(j.Err(), ErrJournalClosed) {
+		t.Errorf("Err() = %v, want %v", j.Err(), ErrJournalClosed)
+	}
+}
+
+func TestRotateFailureRecovers(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatalf("os.MkdirAll() err = %v", err)
+	}
+	path := filepath.Join(dir, "cypher.journal")
+
+	j, err := New(JournalOptions{FilePath: path, MaxBytes: 64})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+	defer j.Close()
+
+	if err := j.Record(Entry{Statement: strings.Repeat("x", 64)}); err != nil {
+		t.Fatalf("Record() err = %v", err)
+	}
+
+	// the rotate can neither rename nor reopen
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("os.RemoveAll() err = %v", err)
+	}
+	if err := j.Record(Entry{Statement: "lost"}); err == nil {
+		t.Fatalf("Record() err = nil, want the rotate failure")
+	}
+
+	// once the directory is back the journal picks up again
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatalf("os.MkdirAll() err = %v", err)
+	}
+	if err := j.Record(Entry{Statement: "kept"}); err != nil {
+		t.Fatalf("Record() after recovery err = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() err = %v", err)
+	}
+	entries := readEntries(t, bytes.NewReader(data))
+	if len(entries) != 1 || entries[0].Statement != "kept" {
+		t.Errorf("journal = %v, want only the entry written after recovery", entries)
+	}
+}
+
+func TestExecuteWriteRetry(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	j, err := New(JournalOptions{Writer: &buf})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+
+	session := &fake.Session{Retries: 2}
+	attempt := int64(0)
+	_, err = ExecuteWrite(ctx, j, session, func(tx neo4j.ManagedTransaction) (any, error) {
+		attempt++
+		for _, cypher := range []string{"CREATE (a:A { attempt: $attempt })", "CREATE (b:B { attempt: $attempt })"} {
+			if _, err := tx.Run(ctx, cypher, map[string]any{"attempt": attempt}); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWrite() err = %v", err)
+	}
+	if len(session.Statements()) != 6 {
+		t.Fatalf("ran %d statements, want 6", len(session.Statements()))
+	}
+
+	entries := readEntries(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("journaled %d entries, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Parameters["attempt"] != float64(3) {
+			t.Errorf("journaled attempt %v, want 3", entry.Parameters["attempt"])
+		}
+	}
+}
+
+func TestReplayMerge(t *testing.T) {
+	// CO_MENTIONED_WITH can only be written after the dataminer wrote the entities
+	dataminer := strings.Join([]string{
+		`{"timestamp": 100, "origin": "dataminer", "sequence": 1, "statement": "d1"}`,
+		`{"timestamp": 300, "origin": "dataminer", "sequence": 2, "statement": "d2"}`,
+		`{"timestamp": 500, "origin": "dataminer", "sequence": 3, "statement": "d3"}`,
+	}, "\n")
+	analyzer := strings.Join([]string{
+		`{"timestamp": 200, "origin": "analyzer", "sequence": 1, "statement": "a1"}`,
+		`{"timestamp": 500, "origin": "analyzer", "sequence": 2, "statement": "a2"}`,
+		`{"timestamp": 600, "origin": "analyzer", "sequence": 3, "statement": "a3"}`,
+	}, "\n")
+
+	session := &fake.Session{}
+	count, err := ReplayCypherJournal(context.Background(), session, strings.NewReader(dataminer), strings.NewReader(analyzer))
+	if err != nil {
+		t.Fatalf("ReplayCypherJournal() err = %v", err)
+	}
+	if count != 6 {
+		t.Errorf("ReplayCypherJournal() = %d, want 6", count)
+	}
+
+	got := make([]string, 0)
+	for _, statement := range session.Statements() {
+		got = append(got, statement.Cypher)
+	}
+	// equal timestamps fall back to the origin
+	want := []string{"d1", "a1", "d2", "a2", "d3", "a3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replayed %v, want %v", got, want)
+	}
+
+	_, err = ReplayCypherJournal(context.Background(), &fake.Session{}, strings.NewReader(dataminer), strings.NewReader("{bad"))
+	if err == nil {
+		t.Errorf("ReplayCypherJournal() malformed journal err = nil")
+	}
+}
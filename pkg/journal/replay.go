@@ -0,0 +1,123 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	klog "k8s.io/klog/v2"
+)
+
+// ReplayCypherJournal executes every statement in the journals read from readers against session,
+// one write transaction per statement. Journals from several components (ie the dataminer and
+// analyzer) are merged by Timestamp, then Origin and Sequence, so cross component writes replay in
+// the order they were made. Returns the number of statements replayed.
+func ReplayCypherJournal(ctx context.Context, session neo4j.SessionWithContext, readers ...io.Reader) (int, error) {
+	klog.V(6).Infof("ReplayCypherJournal ENTER\n")
+
+	decoders := make([]*json.Decoder, 0, len(readers))
+	heads := make([]*Entry, 0, len(readers))
+	for _, reader := range readers {
+		decoder := json.NewDecoder(reader)
+		decoder.UseNumber()
+		decoders = append(decoders, decoder)
+		heads = append(heads, nil)
+	}
+
+	count := 0
+	for {
+		// refill the head of each journal, nil once exhausted
+		next := -1
+		for i, decoder := range decoders {
+			if heads[i] == nil && decoder != nil {
+				var entry Entry
+				err := decoder.Decode(&entry)
+				if err == io.EOF {
+					decoders[i] = nil
+					continue
+				}
+				if err != nil {
+					klog.V(1).Infof("Decode failed in journal %d after %d entries. Err: %v\n", i, count, err)
+					klog.V(6).Infof("ReplayCypherJournal LEAVE\n")
+					return count, err
+				}
+				heads[i] = &entry
+			}
+			if heads[i] != nil && (next == -1 || entryBefore(heads[i], heads[next])) {
+				next = i
+			}
+		}
+		if next == -1 {
+			break
+		}
+
+		entry := heads[next]
+		heads[next] = nil
+
+		params := convertParams(entry.Parameters)
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			result, err := tx.Run(ctx, entry.Statement, params)
+			if err != nil {
+				return nil, err
+			}
+			return result.Collect(ctx)
+		})
+		if err != nil {
+			klog.V(1).Infof("ExecuteWrite failed at entry %d. Err: %v\n", count, err)
+			klog.V(6).Infof("ReplayCypherJournal LEAVE\n")
+			return count, err
+		}
+
+		count++
+	}
+
+	klog.V(4).Infof("ReplayCypherJournal replayed %d statements\n", count)
+	klog.V(6).Infof("ReplayCypherJournal LEAVE\n")
+
+	return count, nil
+}
+
+func entryBefore(a, b *Entry) bool {
+	if a.Timestamp != b.Timestamp {
+		return a.Timestamp < b.Timestamp
+	}
+	if a.Origin != b.Origin {
+		return a.Origin < b.Origin
+	}
+	return a.Sequence < b.Sequence
+}
+
+// convertParams restores the integer/float distinction lost by JSON so replayed properties
+// keep the same types as the original writes. Note: whole number floats come back as integers
+func convertParams(params map[string]any) map[string]any {
+	converted := make(map[string]any, len(params))
+	for key, value := range params {
+		converted[key] = convertValue(value)
+	}
+	return converted
+}
+
+func convertValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		arr := make([]any, 0, len(v))
+		for _, item := range v {
+			arr = append(arr, convertValue(item))
+		}
+		return arr
+	case map[string]any:
+		return convertParams(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Symbl.ai SDK contributors. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package journal
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// JournalOptions to init the Cypher journal. Set either FilePath or Writer
+type JournalOptions struct {
+	// file to append to. when MaxBytes is exceeded, the file is rotated to FilePath.<unix nanos>
+	FilePath string
+	MaxBytes int64
+
+	// stream to append to. streams are never rotated
+	Writer io.Writer
+
+	// component writing the journal (ie dataminer, analyzer), stamped on every entry
+	Origin string
+
+	// fail ExecuteWrite with ErrJournalWrite when a committed transaction can't be journaled.
+	// by default the failure is only logged and kept on Journal.Err so ingestion continues
+	Strict bool
+}
+
+// Entry is a single executed Cypher statement, one JSON object per line. Timestamp (unix nanos)
+// never goes backwards within a journal and Sequence counts the entries written by this process,
+// together they order entries when journals from several components are merged.
+type Entry struct {
+	Timestamp  int64          `json:"timestamp"`
+	Origin     string         `json:"origin,omitempty"`
+	Sequence   uint64         `json:"sequence"`
+	Statement  string         `json:"statement"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// Journal appends executed Cypher statements in a replayable format. It is thread safe
+type Journal struct {
+	options JournalOptions
+
+	writer        io.Writer
+	file          *os.File
+	size          int64
+	sequence      uint64
+	lastTimestamp int64
+	closed        bool
+	err           error
+	mu            sync.Mutex
+}
+
+// RecordingTransaction captures every statement Run on the wrapped transaction
+type RecordingTransaction struct {
+	neo4j.ManagedTransaction
+
+	entries []Entry
+}